@@ -0,0 +1,79 @@
+package genmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAngleTracker(t *testing.T) {
+	tracker := NewAngleTracker(360.0)
+	steps := []struct{ wrapped, want float64 }{
+		{350, 350},
+		{10, 370},  // forward across 360
+		{30, 390},  // no wrap
+		{340, 340}, // backward across 0
+		{200, 200},
+		{20, 20},
+		{350, -10}, // backward below 0
+	}
+	for i, step := range steps {
+		if got := tracker.Update(step.wrapped); !approxEqual(got, step.want, 1e-12) {
+			t.Errorf("step %d: Update(%v) = %v; want %v", i, step.wrapped, got, step.want)
+		}
+	}
+	if got := tracker.Angle(); got != -10 {
+		t.Errorf("Angle() = %v; want -10", got)
+	}
+}
+
+func TestWrapPhase(t *testing.T) {
+	cases := []struct{ phase, want, tol float64 }{
+		{-0.1, TAU - 0.1, 1e-12},
+		{TAU, 0, 0},
+		{0, 0, 0},
+		{1000*TAU + 0.5, 0.5, 1e-9},
+	}
+	for _, tc := range cases {
+		if got := WrapPhase(tc.phase); !approxEqual(got, tc.want, tc.tol) {
+			t.Errorf("WrapPhase(%v) = %v; want %v", tc.phase, got, tc.want)
+		}
+	}
+	if got := WrapPhase(float32(-0.1)); got < 0 || got >= TAU {
+		t.Errorf("WrapPhase(float32(-0.1)) = %v; want in [0, TAU)", got)
+	}
+}
+
+func TestSinCosFastErrorBound(t *testing.T) {
+	const steps = 100000
+	maxErr := 0.0
+	for i := 0; i <= steps; i += 1 {
+		x := -PI + TAU*float64(i)/steps
+		maxErr = math.Max(maxErr, math.Abs(SinFast(x)-math.Sin(x)))
+		maxErr = math.Max(maxErr, math.Abs(CosFast(x)-math.Cos(x)))
+	}
+	if maxErr >= 0.0017 {
+		t.Errorf("SinFast/CosFast max error over a period = %v; want below 0.0017", maxErr)
+	}
+}
+
+func TestSinFastMatchesWrapRadians(t *testing.T) {
+	for _, x := range []float64{-1000.5, -7, -PI, -1, 0, 2, PI, 4, 9.5, 1234.25} {
+		if got, want := SinFast(x), SinFast(WrapRadians(x)); !approxEqual(got, want, 1e-9) {
+			t.Errorf("SinFast(%v) = %v; want SinFast(WrapRadians(%v)) = %v", x, got, x, want)
+		}
+	}
+}
+
+func BenchmarkSinFast(b *testing.B) {
+	vals := benchInputs()
+	for i := 0; i < b.N; i += 1 {
+		benchSink = SinFast(vals[i&1023] * 10)
+	}
+}
+
+func BenchmarkMathSin(b *testing.B) {
+	vals := benchInputs()
+	for i := 0; i < b.N; i += 1 {
+		benchSink = math.Sin(vals[i&1023] * 10)
+	}
+}
@@ -0,0 +1,94 @@
+package genmath
+
+import "math"
+
+// SRGBToLinear decodes an sRGB channel value in [0, 1] to linear light. Inputs
+// are clamped to [0, 1].
+func SRGBToLinear[T Float](c T) T {
+	fC := Clamp(0, float64(c), 1)
+	if fC <= 0.04045 {
+		return T(fC / 12.92)
+	}
+	return T(math.Pow((fC+0.055)/1.055, 2.4))
+}
+
+// LinearToSRGB encodes a linear light value in [0, 1] to an sRGB channel
+// value. Inputs are clamped to [0, 1].
+func LinearToSRGB[T Float](c T) T {
+	fC := Clamp(0, float64(c), 1)
+	if fC <= 0.0031308 {
+		return T(fC * 12.92)
+	}
+	return T(1.055*math.Pow(fC, 1/2.4) - 0.055)
+}
+
+// KelvinToRGB approximates the color of a black body at the given temperature
+// with Tanner Helland's curve fit, returning channels in [0, 1]. The
+// temperature is clamped to [1000, 40000] kelvin.
+func KelvinToRGB(kelvin float64) (r, g, b float64) {
+	temp := Clamp(1000, kelvin, 40000) / 100
+	if temp <= 66 {
+		r = 255
+		g = 99.4708025861*math.Log(temp) - 161.1195681661
+	} else {
+		r = 329.698727446 * math.Pow(temp-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(temp-60, -0.0755148492)
+	}
+	switch {
+	case temp >= 66:
+		b = 255
+	case temp <= 19:
+		b = 0
+	default:
+		b = 138.5177312231*math.Log(temp-10) - 305.0447927307
+	}
+	return Clamp(0, r, 255) / 255, Clamp(0, g, 255) / 255, Clamp(0, b, 255) / 255
+}
+
+// LerpHue interpolates between two hues in degrees along the shorter arc of
+// the color wheel, returning a hue in [0, 360). Hues exactly 180 apart turn
+// in the negative direction.
+func LerpHue[T Float](from, to T, amount float64) T {
+	diff := float64(AngleDiffRange(from, to, 360))
+	return WrapPhaseRange(from+T(diff*amount), 360)
+}
+
+// NormalizeWithGamma maps val from [min, max] onto [0, 1] with
+// InverseLerpClamped and raises the result to 1/gamma, so gamma above 1
+// brightens and below 1 darkens. A gamma of 0 or less is treated as 1.
+func NormalizeWithGamma[T Float](val, min, max, gamma T) T {
+	norm := InverseLerpClamped(min, max, val)
+	if gamma <= 0 {
+		return T(norm)
+	}
+	return T(math.Pow(norm, 1/float64(gamma)))
+}
+
+// FresnelSchlick returns Schlick's approximation of Fresnel reflectance,
+// f0 + (1-f0) * (1-cosTheta)^5, where f0 is the reflectance at normal
+// incidence. cosTheta is clamped to [0, 1].
+func FresnelSchlick[T Float](cosTheta, f0 T) T {
+	fCos, fF0 := Clamp(0, float64(cosTheta), 1), float64(f0)
+	return T(fF0 + (1-fF0)*math.Pow(1-fCos, 5))
+}
+
+// ToneMapReinhard maps a linear HDR value to [0, 1] with the simple Reinhard
+// operator x/(1+x). Negative inputs return 0 and +Inf returns 1.
+func ToneMapReinhard[T Float](x T) T {
+	fX := Max(float64(x), 0)
+	if math.IsInf(fX, 1) {
+		return 1
+	}
+	return T(Clamp(0, fX/(1+fX), 1))
+}
+
+// ToneMapACES maps a linear HDR value to [0, 1] with Krzysztof Narkowicz's
+// rational fit of the ACES filmic curve. Negative inputs return 0 and +Inf
+// returns 1.
+func ToneMapACES[T Float](x T) T {
+	fX := Max(float64(x), 0)
+	if math.IsInf(fX, 1) {
+		return 1
+	}
+	return T(Clamp(0, fX*(2.51*fX+0.03)/(fX*(2.43*fX+0.59)+0.14), 1))
+}
@@ -0,0 +1,144 @@
+package genmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSRGBRoundTrip(t *testing.T) {
+	for i := 0; i <= 100; i += 1 {
+		c := float64(i) / 100
+		if got := LinearToSRGB(SRGBToLinear(c)); !approxEqual(got, c, 1e-12) {
+			t.Errorf("LinearToSRGB(SRGBToLinear(%v)) = %v", c, got)
+		}
+	}
+}
+
+func TestSRGBBreakpoint(t *testing.T) {
+	if got := SRGBToLinear(0.04045); !approxEqual(got, 0.04045/12.92, 1e-15) {
+		t.Errorf("SRGBToLinear(0.04045) = %v; want the linear segment %v", got, 0.04045/12.92)
+	}
+	below, above := SRGBToLinear(0.04045-1e-9), SRGBToLinear(0.04045+1e-9)
+	if !approxEqual(below, above, 1e-8) {
+		t.Errorf("SRGBToLinear is discontinuous at 0.04045: %v vs %v", below, above)
+	}
+	if got := SRGBToLinear(1.0); got != 1 {
+		t.Errorf("SRGBToLinear(1) = %v; want 1", got)
+	}
+	if got := SRGBToLinear(-0.5); got != 0 {
+		t.Errorf("SRGBToLinear(-0.5) = %v; want 0", got)
+	}
+}
+
+func TestKelvinToRGB(t *testing.T) {
+	// Reference 8-bit values from Tanner Helland's published table.
+	cases := []struct {
+		kelvin  float64
+		r, g, b float64
+	}{
+		{1000, 255, 68, 0},
+		{2700, 255, 167, 87},
+		{6600, 255, 255, 255},
+		{10000, 201, 218, 255},
+	}
+	for _, tc := range cases {
+		r, g, b := KelvinToRGB(tc.kelvin)
+		if !approxEqual(r*255, tc.r, 1) || !approxEqual(g*255, tc.g, 1) || !approxEqual(b*255, tc.b, 1) {
+			t.Errorf("KelvinToRGB(%v) = (%.0f, %.0f, %.0f); want (%v, %v, %v)", tc.kelvin, r*255, g*255, b*255, tc.r, tc.g, tc.b)
+		}
+	}
+	r0, g0, b0 := KelvinToRGB(500)
+	r1, g1, b1 := KelvinToRGB(1000)
+	if r0 != r1 || g0 != g1 || b0 != b1 {
+		t.Errorf("KelvinToRGB(500) is not clamped to 1000K")
+	}
+}
+
+func TestLerpHue(t *testing.T) {
+	cases := []struct{ from, to, amount, want float64 }{
+		{350, 10, 0.5, 0},
+		{10, 350, 0.5, 0},
+		{350, 10, 0.25, 355},
+		{30, 90, 0.5, 60},
+		{90, 30, 0.25, 75},
+		{0, 180, 0.5, 270},
+		{200, 20, 0.5, 110},
+	}
+	for _, tc := range cases {
+		if got := LerpHue(tc.from, tc.to, tc.amount); !approxEqual(got, tc.want, 1e-9) {
+			t.Errorf("LerpHue(%v, %v, %v) = %v; want %v", tc.from, tc.to, tc.amount, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeWithGamma(t *testing.T) {
+	if got := NormalizeWithGamma(10.0, 10, 20, 2.2); got != 0 {
+		t.Errorf("NormalizeWithGamma(min) = %v; want 0", got)
+	}
+	if got := NormalizeWithGamma(20.0, 10, 20, 2.2); got != 1 {
+		t.Errorf("NormalizeWithGamma(max) = %v; want 1", got)
+	}
+	if got := NormalizeWithGamma(25.0, 10, 20, 2.2); got != 1 {
+		t.Errorf("NormalizeWithGamma(above max) = %v; want 1", got)
+	}
+	linear := NormalizeWithGamma(15.0, 10, 20, 1)
+	if linear != 0.5 {
+		t.Errorf("NormalizeWithGamma(mid, gamma 1) = %v; want 0.5", linear)
+	}
+	if got := NormalizeWithGamma(15.0, 10, 20, 2); !(got > linear) || !approxEqual(got, math.Sqrt(0.5), 1e-12) {
+		t.Errorf("NormalizeWithGamma(mid, gamma 2) = %v; want brighter sqrt(0.5)", got)
+	}
+	if got := NormalizeWithGamma(15.0, 10, 20, 0.5); !(got < linear) || !approxEqual(got, 0.25, 1e-12) {
+		t.Errorf("NormalizeWithGamma(mid, gamma 0.5) = %v; want darker 0.25", got)
+	}
+	if got := NormalizeWithGamma(15.0, 10, 20, -3); got != linear {
+		t.Errorf("NormalizeWithGamma(mid, gamma -3) = %v; want gamma 1 result %v", got, linear)
+	}
+}
+
+func TestFresnelSchlick(t *testing.T) {
+	if got := FresnelSchlick(1.0, 0.04); got != 0.04 {
+		t.Errorf("FresnelSchlick(1, 0.04) = %v; want 0.04", got)
+	}
+	if got := FresnelSchlick(0.0, 0.04); got != 1 {
+		t.Errorf("FresnelSchlick(0, 0.04) = %v; want 1", got)
+	}
+	if got := FresnelSchlick(0.5, 0.04); !approxEqual(got, 0.07, 1e-12) {
+		t.Errorf("FresnelSchlick(0.5, 0.04) = %v; want 0.07", got)
+	}
+	if got := FresnelSchlick(-2.0, 0.04); got != 1 {
+		t.Errorf("FresnelSchlick(-2, 0.04) = %v; want 1 (clamped)", got)
+	}
+}
+
+func TestToneMap(t *testing.T) {
+	curves := []struct {
+		name string
+		fn   func(float64) float64
+	}{
+		{"ToneMapReinhard", ToneMapReinhard[float64]},
+		{"ToneMapACES", ToneMapACES[float64]},
+	}
+	for _, curve := range curves {
+		if got := curve.fn(0); got != 0 {
+			t.Errorf("%s(0) = %v; want 0", curve.name, got)
+		}
+		if got := curve.fn(-1); got != 0 {
+			t.Errorf("%s(-1) = %v; want 0", curve.name, got)
+		}
+		if got := curve.fn(1e6); !(got > 0.99 && got <= 1) {
+			t.Errorf("%s(1e6) = %v; want close to 1", curve.name, got)
+		}
+		if got := curve.fn(PInf64()); got != 1 {
+			t.Errorf("%s(+Inf) = %v; want 1", curve.name, got)
+		}
+		prev := 0.0
+		for x := 0.01; x < 1000; x *= 1.2 {
+			got := curve.fn(x)
+			if got < prev || got > 1 {
+				t.Errorf("%s(%v) = %v; want monotonic and at most 1", curve.name, x, got)
+			}
+			prev = got
+		}
+	}
+}
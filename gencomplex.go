@@ -0,0 +1,21 @@
+package genmath
+
+import "math/cmplx"
+
+func CAbs[T Complex](c T) float64 {
+	return cmplx.Abs(complex128(c))
+}
+
+func CConj[T Complex](c T) T {
+	cConj := cmplx.Conj(complex128(c))
+	return T(cConj)
+}
+
+func CArg[T Complex](c T) float64 {
+	return cmplx.Phase(complex128(c))
+}
+
+func CFromPolar[T Complex](r, theta float64) T {
+	cRect := cmplx.Rect(r, theta)
+	return T(cRect)
+}
@@ -0,0 +1,44 @@
+package genmath
+
+import (
+	"testing"
+)
+
+func TestCAbs(t *testing.T) {
+	if got := CAbs(complex(3.0, 4)); got != 5 {
+		t.Errorf("CAbs(3+4i) = %v; want 5", got)
+	}
+	if got := CAbs(complex64(complex(-3, -4))); !approxEqual(got, 5, 1e-6) {
+		t.Errorf("CAbs(complex64(-3-4i)) = %v; want 5", got)
+	}
+}
+
+func TestCConj(t *testing.T) {
+	if got := CConj(complex(1.5, -2)); got != complex(1.5, 2) {
+		t.Errorf("CConj(1.5-2i) = %v; want 1.5+2i", got)
+	}
+}
+
+func TestCArg(t *testing.T) {
+	cases := []struct {
+		c    complex128
+		want float64
+	}{
+		{complex(1, 0), 0},
+		{complex(0, 1), PI / 2},
+		{complex(-1, 0), PI},
+		{complex(1, -1), -PI / 4},
+	}
+	for _, tc := range cases {
+		if got := CArg(tc.c); !approxEqual(got, tc.want, 1e-12) {
+			t.Errorf("CArg(%v) = %v; want %v", tc.c, got, tc.want)
+		}
+	}
+}
+
+func TestCFromPolar(t *testing.T) {
+	c := CFromPolar[complex128](2, PI/3)
+	if !approxEqual(CAbs(c), 2, 1e-12) || !approxEqual(CArg(c), PI/3, 1e-12) {
+		t.Errorf("CFromPolar(2, PI/3) = %v; want magnitude 2 and phase PI/3", c)
+	}
+}
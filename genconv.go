@@ -0,0 +1,262 @@
+package genmath
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+func isSigned[T Real]() bool {
+	return T(0)-1 < 0
+}
+
+func isFloat[T Real]() bool {
+	return T(1)/2 != 0
+}
+
+func bitsOf[T Integer]() int {
+	var zero T
+	return int(unsafe.Sizeof(zero)) * 8
+}
+
+func maxOf[T Integer]() T {
+	if isSigned[T]() {
+		return T(1)<<(bitsOf[T]()-1) - 1
+	}
+	return ^T(0)
+}
+
+func minOf[T Integer]() T {
+	if isSigned[T]() {
+		return -maxOf[T]() - 1
+	}
+	return 0
+}
+
+func ConvertChecked[T Integer, U Integer](val T) (U, bool) {
+	conv := U(val)
+	if T(conv) != val || (val < 0) != (conv < 0) {
+		return conv, false
+	}
+	return conv, true
+}
+
+func ConvertSaturating[T Integer, U Integer](val T) U {
+	conv, ok := ConvertChecked[T, U](val)
+	if ok {
+		return conv
+	}
+	if val < 0 {
+		return minOf[U]()
+	}
+	return maxOf[U]()
+}
+
+type RoundMode uint8
+
+const (
+	RoundNearest  RoundMode = iota // Half away from zero
+	RoundHalfEven                  // Half to even (banker's rounding)
+	RoundFloor                     // Toward negative infinity
+	RoundCeil                      // Toward positive infinity
+	RoundTrunc                     // Toward zero
+)
+
+func roundByMode(val float64, mode RoundMode) float64 {
+	switch mode {
+	case RoundHalfEven:
+		return math.RoundToEven(val)
+	case RoundFloor:
+		return math.Floor(val)
+	case RoundCeil:
+		return math.Ceil(val)
+	case RoundTrunc:
+		return math.Trunc(val)
+	default:
+		return math.Round(val)
+	}
+}
+
+// FloatToInt rounds val using mode and returns false if the result is NaN,
+// infinite, or outside the range of I.
+func FloatToInt[F Float, I SignedInteger](val F, mode RoundMode) (I, bool) {
+	fRound := roundByMode(float64(val), mode)
+	fLimit := -float64(minOf[I]())
+	if math.IsNaN(fRound) || fRound < -fLimit || fRound >= fLimit {
+		return 0, false
+	}
+	return I(fRound), true
+}
+
+// FormatFixed formats val with exactly decimals digits after the decimal
+// point, or with the fewest digits that round-trip if decimals is negative.
+// Halfway values round away from zero like Round, so 2.5 formats as "3" with
+// no decimals. NaN and infinities format as "NaN", "+Inf" and "-Inf".
+func FormatFixed[T Real](val T, decimals int) string {
+	if isFloat[T]() {
+		fVal := float64(val)
+		switch {
+		case math.IsNaN(fVal):
+			return "NaN"
+		case math.IsInf(fVal, 1):
+			return "+Inf"
+		case math.IsInf(fVal, -1):
+			return "-Inf"
+		}
+		if decimals >= 0 {
+			scale := math.Pow(10, float64(decimals))
+			if scaled := fVal * scale; math.Abs(scaled) < 1<<52 {
+				fVal = roundByMode(scaled, RoundNearest) / scale
+			}
+		}
+		bitSize := 64
+		if unsafe.Sizeof(val) == 4 {
+			bitSize = 32
+		}
+		return strconv.FormatFloat(fVal, 'f', decimals, bitSize)
+	}
+	var str string
+	if isSigned[T]() {
+		str = strconv.FormatInt(int64(val), 10)
+	} else {
+		str = strconv.FormatUint(uint64(val), 10)
+	}
+	if decimals > 0 {
+		str += "." + strings.Repeat("0", decimals)
+	}
+	return str
+}
+
+// QuantizeUnit clamps val to [0, 1] and maps it to the nearest of levels
+// evenly spaced integers in [0, levels-1]. NaN maps to 0.
+func QuantizeUnit[T Float, U Integer](val T, levels U) U {
+	if levels <= 1 || val != val {
+		return 0
+	}
+	fVal := Clamp(0, float64(val), 1)
+	return U(math.Round(fVal * float64(levels-1)))
+}
+
+// ParseInt parses s in the given base into T, returning a *strconv.NumError
+// wrapping strconv.ErrRange if the value does not fit in T.
+func ParseInt[T Integer](s string, base int) (T, error) {
+	if isSigned[T]() {
+		i, err := strconv.ParseInt(s, base, bitsOf[T]())
+		return T(i), err
+	}
+	u, err := strconv.ParseUint(s, base, bitsOf[T]())
+	return T(u), err
+}
+
+// ParseFloat parses s into T, returning a *strconv.NumError wrapping
+// strconv.ErrRange if the value overflows T.
+func ParseFloat[T Float](s string) (T, error) {
+	var zero T
+	f, err := strconv.ParseFloat(s, int(unsafe.Sizeof(zero))*8)
+	return T(f), err
+}
+
+// ClampToType rounds val to the nearest integer and clamps it to the range of
+// I. NaN converts to 0.
+func ClampToType[F Float, I Integer](val F) I {
+	fRound := math.Round(float64(val))
+	switch {
+	case fRound != fRound:
+		return 0
+	case fRound <= float64(minOf[I]()):
+		return minOf[I]()
+	case fRound >= float64(maxOf[I]()):
+		return maxOf[I]()
+	}
+	return I(fRound)
+}
+
+// FloatToFixed converts val to a fixed-point integer with fracBits fractional
+// bits, rounding to nearest and saturating at the limits of I.
+func FloatToFixed[I SignedInteger](val float64, fracBits int) I {
+	return ClampToType[float64, I](math.Ldexp(val, fracBits))
+}
+
+// FloatToQ15 converts a value in [-1, 1) to Q15, saturating out-of-range
+// values (so 1.0 becomes MAX_I16).
+func FloatToQ15(val float64) int16 {
+	return FloatToFixed[int16](val, 15)
+}
+
+func Q15ToFloat(q int16) float64 {
+	return math.Ldexp(float64(q), -15)
+}
+
+// Snap rounds val to a multiple of multiple using mode. Integer types are
+// snapped without a float round trip, and if the rounded multiple would
+// overflow T the nearest multiple toward zero is returned instead. Only the
+// magnitude of multiple matters, and a multiple of 0 returns val.
+func Snap[T Real](val, multiple T, mode RoundMode) T {
+	if multiple == 0 {
+		return val
+	}
+	if isFloat[T]() {
+		fMul := math.Abs(float64(multiple))
+		return T(roundByMode(float64(val)/fMul, mode) * fMul)
+	}
+	mul := Abs(multiple)
+	quo := val / mul
+	rem := val - quo*mul
+	absRem := Abs(rem)
+	away := false
+	switch mode {
+	case RoundFloor:
+		away = rem < 0
+	case RoundCeil:
+		away = rem > 0
+	case RoundTrunc:
+	case RoundHalfEven:
+		away = absRem > mul-absRem || (absRem == mul-absRem && quo/2*2 != quo)
+	default:
+		away = absRem >= mul-absRem && rem != 0
+	}
+	base := quo * mul
+	if away {
+		if next := base - mul; rem < 0 && next < base {
+			return next
+		}
+		if next := base + mul; rem > 0 && next > base {
+			return next
+		}
+	}
+	return base
+}
+
+// ClosestMultiple rounds val to the nearest multiple of multiple, with
+// halfway values rounded away from zero.
+func ClosestMultiple[T Real](val, multiple T) T {
+	return Snap(val, multiple, RoundNearest)
+}
+
+// SplitSign separates val into its sign and unsigned magnitude, including the
+// signed minimum whose magnitude is one more than the signed maximum. U must
+// be at least as wide as T to hold every magnitude.
+func SplitSign[T SignedInteger, U Unsigned](val T) (negative bool, magnitude U) {
+	if val < 0 {
+		return true, U(-uint64(int64(val)))
+	}
+	return false, U(val)
+}
+
+// ToPercent converts a fraction to a percentage, so 0.25 becomes 25. For
+// integer types only whole-number fractions convert meaningfully.
+func ToPercent[T Real](fraction T) T {
+	return fraction * 100
+}
+
+// FromPercent converts a percentage to a fraction, so 25 becomes 0.25. Integer
+// types truncate toward zero.
+func FromPercent[T Real](percent T) T {
+	return percent / 100
+}
+
+// ClampPercent clamps percent to [0, 100].
+func ClampPercent[T Real](percent T) T {
+	return Clamp(0, percent, 100)
+}
@@ -0,0 +1,334 @@
+package genmath
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestConvertChecked(t *testing.T) {
+	cases := []struct {
+		val  int32
+		want int8
+		ok   bool
+	}{
+		{100, 100, true},
+		{-128, -128, true},
+		{127, 127, true},
+		{128, -128, false},
+		{-129, 127, false},
+		{300, 44, false},
+	}
+	for _, tc := range cases {
+		if got, ok := ConvertChecked[int32, int8](tc.val); got != tc.want || ok != tc.ok {
+			t.Errorf("ConvertChecked[int32, int8](%d) = %d, %v; want %d, %v", tc.val, got, ok, tc.want, tc.ok)
+		}
+	}
+	if _, ok := ConvertChecked[int32, uint8](-1); ok {
+		t.Errorf("ConvertChecked[int32, uint8](-1) ok; want overflow")
+	}
+}
+
+func TestConvertSaturating(t *testing.T) {
+	cases := []struct {
+		val  int32
+		want int8
+	}{
+		{100, 100},
+		{128, 127},
+		{MAX_I32, 127},
+		{-129, -128},
+		{MIN_I32, -128},
+	}
+	for _, tc := range cases {
+		if got := ConvertSaturating[int32, int8](tc.val); got != tc.want {
+			t.Errorf("ConvertSaturating[int32, int8](%d) = %d; want %d", tc.val, got, tc.want)
+		}
+	}
+	if got := ConvertSaturating[int32, uint8](-5); got != 0 {
+		t.Errorf("ConvertSaturating[int32, uint8](-5) = %d; want 0", got)
+	}
+}
+
+func TestFloatToInt(t *testing.T) {
+	invalid := []float64{QNaN64(), PInf64(), NInf64(), MAX_I32 + 1, MIN_I32 - 1}
+	for _, val := range invalid {
+		if got, ok := FloatToInt[float64, int32](val, RoundNearest); ok {
+			t.Errorf("FloatToInt[int32](%v) = %d, true; want false", val, got)
+		}
+	}
+	if got, ok := FloatToInt[float64, int32](MAX_I32, RoundNearest); !ok || got != MAX_I32 {
+		t.Errorf("FloatToInt[int32](MAX_I32) = %d, %v; want MAX_I32, true", got, ok)
+	}
+	modes := []struct {
+		mode     RoundMode
+		pos, neg int32
+	}{
+		{RoundNearest, 3, -3},
+		{RoundHalfEven, 2, -2},
+		{RoundFloor, 2, -3},
+		{RoundCeil, 3, -2},
+		{RoundTrunc, 2, -2},
+	}
+	for _, tc := range modes {
+		if got, _ := FloatToInt[float64, int32](2.5, tc.mode); got != tc.pos {
+			t.Errorf("FloatToInt(2.5, mode %d) = %d; want %d", tc.mode, got, tc.pos)
+		}
+		if got, _ := FloatToInt[float64, int32](-2.5, tc.mode); got != tc.neg {
+			t.Errorf("FloatToInt(-2.5, mode %d) = %d; want %d", tc.mode, got, tc.neg)
+		}
+	}
+}
+
+func TestFormatFixed(t *testing.T) {
+	cases := []struct {
+		got, want string
+	}{
+		{FormatFixed(3.14159, 2), "3.14"},
+		{FormatFixed(-2.5, 0), "-3"},
+		{FormatFixed(2.5, 0), "3"},
+		{FormatFixed(0.125, 2), "0.13"},
+		{FormatFixed(-0.125, 2), "-0.13"},
+		{FormatFixed(1e20, 1), "100000000000000000000.0"},
+		{FormatFixed(42, 3), "42.000"},
+		{FormatFixed(uint8(7), 0), "7"},
+		{FormatFixed(0.1, -1), "0.1"},
+		{FormatFixed(float32(3.3), -1), "3.3"},
+		{FormatFixed(float32(3.3), 2), "3.30"},
+		{FormatFixed(QNaN64(), 2), "NaN"},
+		{FormatFixed(PInf32(), 2), "+Inf"},
+		{FormatFixed(NInf64(), -1), "-Inf"},
+	}
+	for i, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("case %d: FormatFixed = %q; want %q", i, tc.got, tc.want)
+		}
+	}
+}
+
+func TestQuantizeUnit(t *testing.T) {
+	cases := []struct {
+		val  float64
+		want uint16
+	}{
+		{0, 0},
+		{1, 255},
+		{0.5, 128},
+		{-0.2, 0},
+		{1.5, 255},
+		{QNaN64(), 0},
+	}
+	for _, tc := range cases {
+		if got := QuantizeUnit(tc.val, uint16(256)); got != tc.want {
+			t.Errorf("QuantizeUnit(%v, 256) = %d; want %d", tc.val, got, tc.want)
+		}
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	if got, err := ParseInt[int8]("-128", 10); err != nil || got != -128 {
+		t.Errorf("ParseInt[int8](-128) = %d, %v; want -128, nil", got, err)
+	}
+	if _, err := ParseInt[int8]("128", 10); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("ParseInt[int8](128) error = %v; want ErrRange", err)
+	}
+	if _, err := ParseInt[uint8]("-1", 10); err == nil {
+		t.Errorf("ParseInt[uint8](-1) succeeded; want an error")
+	}
+	if got, err := ParseInt[uint16]("ff", 16); err != nil || got != 255 {
+		t.Errorf("ParseInt[uint16](ff, 16) = %d, %v; want 255, nil", got, err)
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	if got, err := ParseFloat[float32]("3.3"); err != nil || got != float32(3.3) {
+		t.Errorf("ParseFloat[float32](3.3) = %v, %v; want 3.3, nil", got, err)
+	}
+	if _, err := ParseFloat[float32]("1e39"); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("ParseFloat[float32](1e39) error = %v; want ErrRange", err)
+	}
+	if _, err := ParseFloat[float64]("1e39"); err != nil {
+		t.Errorf("ParseFloat[float64](1e39) error = %v; want nil", err)
+	}
+}
+
+func TestClampToType(t *testing.T) {
+	cases := []struct {
+		val  float64
+		want int8
+	}{
+		{1000, MAX_I8},
+		{-1000, MIN_I8},
+		{PInf64(), MAX_I8},
+		{QNaN64(), 0},
+		{2.5, 3},
+		{-2.5, -3},
+		{2.4, 2},
+		{127.4, 127},
+	}
+	for _, tc := range cases {
+		if got := ClampToType[float64, int8](tc.val); got != tc.want {
+			t.Errorf("ClampToType[int8](%v) = %d; want %d", tc.val, got, tc.want)
+		}
+	}
+	if got := ClampToType[float64, uint64](1e30); got != MAX_U64 {
+		t.Errorf("ClampToType[uint64](1e30) = %d; want MAX_U64", got)
+	}
+	if got := ClampToType[float32, uint8](-4); got != 0 {
+		t.Errorf("ClampToType[uint8](-4) = %d; want 0", got)
+	}
+}
+
+func TestQ15(t *testing.T) {
+	cases := []struct {
+		val  float64
+		want int16
+	}{
+		{0, 0},
+		{0.5, 16384},
+		{-1, MIN_I16},
+		{1, MAX_I16},
+		{2, MAX_I16},
+		{-2, MIN_I16},
+	}
+	for _, tc := range cases {
+		if got := FloatToQ15(tc.val); got != tc.want {
+			t.Errorf("FloatToQ15(%v) = %d; want %d", tc.val, got, tc.want)
+		}
+	}
+	for _, q := range []int16{MIN_I16, -12345, 0, 1, 16384, MAX_I16} {
+		if got := FloatToQ15(Q15ToFloat(q)); got != q {
+			t.Errorf("FloatToQ15(Q15ToFloat(%d)) = %d; want %d", q, got, q)
+		}
+	}
+	if got := FloatToFixed[int32](1.5, 8); got != 384 {
+		t.Errorf("FloatToFixed[int32](1.5, 8) = %d; want 384", got)
+	}
+}
+
+func TestSnap(t *testing.T) {
+	if got := Snap(uint8(250), 100, RoundNearest); got != 200 {
+		t.Errorf("Snap(uint8(250), 100) = %d; want 200 (300 overflows)", got)
+	}
+	if got := Snap(int8(-125), 50, RoundFloor); got != -100 {
+		t.Errorf("Snap(int8(-125), 50, RoundFloor) = %d; want -100 (-150 overflows)", got)
+	}
+	if got := Snap(int8(120), 50, RoundCeil); got != 100 {
+		t.Errorf("Snap(int8(120), 50, RoundCeil) = %d; want 100 (150 overflows)", got)
+	}
+	modes := []struct {
+		mode RoundMode
+		want int
+	}{
+		{RoundNearest, 30},
+		{RoundHalfEven, 20},
+		{RoundFloor, 20},
+		{RoundCeil, 30},
+		{RoundTrunc, 20},
+	}
+	for _, tc := range modes {
+		if got := Snap(25, 10, tc.mode); got != tc.want {
+			t.Errorf("Snap(25, 10, mode %d) = %d; want %d", tc.mode, got, tc.want)
+		}
+	}
+	negative := []struct {
+		val  int
+		mode RoundMode
+		want int
+	}{
+		{7, RoundNearest, 10},
+		{7, RoundHalfEven, 10},
+		{7, RoundFloor, 0},
+		{7, RoundCeil, 10},
+		{7, RoundTrunc, 0},
+		{-7, RoundNearest, -10},
+		{-7, RoundHalfEven, -10},
+		{-7, RoundFloor, -10},
+		{-7, RoundCeil, 0},
+		{-7, RoundTrunc, 0},
+		{25, RoundNearest, 30},
+		{25, RoundHalfEven, 20},
+		{25, RoundFloor, 20},
+		{25, RoundCeil, 30},
+		{25, RoundTrunc, 20},
+		{-25, RoundNearest, -30},
+		{-25, RoundHalfEven, -20},
+		{-25, RoundFloor, -30},
+		{-25, RoundCeil, -20},
+		{-25, RoundTrunc, -20},
+	}
+	for _, tc := range negative {
+		if got := Snap(tc.val, -10, tc.mode); got != tc.want {
+			t.Errorf("Snap(%d, -10, mode %d) = %d; want %d", tc.val, tc.mode, got, tc.want)
+		}
+		if got := Snap(float64(tc.val), -10, tc.mode); got != float64(tc.want) {
+			t.Errorf("Snap(%v, -10.0, mode %d) = %v; want %d", float64(tc.val), tc.mode, got, tc.want)
+		}
+	}
+}
+
+func TestClosestMultipleHalfway(t *testing.T) {
+	cases := []struct{ val, multiple, want int }{
+		{15, 10, 20},
+		{-15, 10, -20},
+		{25, 10, 30},
+		{14, 10, 10},
+		{16, -10, 20},
+	}
+	for _, tc := range cases {
+		if got := ClosestMultiple(tc.val, tc.multiple); got != tc.want {
+			t.Errorf("ClosestMultiple(%d, %d) = %d; want %d", tc.val, tc.multiple, got, tc.want)
+		}
+	}
+	if got := ClosestMultiple(0.75, 0.5); got != 1 {
+		t.Errorf("ClosestMultiple(0.75, 0.5) = %v; want 1", got)
+	}
+	if got := ClosestMultiple(-0.75, 0.5); got != -1 {
+		t.Errorf("ClosestMultiple(-0.75, 0.5) = %v; want -1", got)
+	}
+}
+
+func TestSplitSign(t *testing.T) {
+	if neg, mag := SplitSign[int8, uint8](MIN_I8); !neg || mag != 128 {
+		t.Errorf("SplitSign(MIN_I8) = %v, %d; want true, 128", neg, mag)
+	}
+	if neg, mag := SplitSign[int32, uint32](MIN_I32); !neg || mag != 1<<31 {
+		t.Errorf("SplitSign(MIN_I32) = %v, %d; want true, %d", neg, mag, uint32(1<<31))
+	}
+	if neg, mag := SplitSign[int64, uint64](MIN_I64); !neg || mag != 1<<63 {
+		t.Errorf("SplitSign(MIN_I64) = %v, %d; want true, %d", neg, mag, uint64(1<<63))
+	}
+	if neg, mag := SplitSign[int8, uint8](MAX_I8); neg || mag != 127 {
+		t.Errorf("SplitSign(MAX_I8) = %v, %d; want false, 127", neg, mag)
+	}
+	if neg, mag := SplitSign[int, uint](0); neg || mag != 0 {
+		t.Errorf("SplitSign(0) = %v, %d; want false, 0", neg, mag)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	if got := ToPercent(0.25); got != 25 {
+		t.Errorf("ToPercent(0.25) = %v; want 25", got)
+	}
+	if got := FromPercent(25.0); got != 0.25 {
+		t.Errorf("FromPercent(25) = %v; want 0.25", got)
+	}
+	for _, fraction := range []float64{0, 0.125, 0.5, 1, 1.5} {
+		if got := FromPercent(ToPercent(fraction)); got != fraction {
+			t.Errorf("FromPercent(ToPercent(%v)) = %v", fraction, got)
+		}
+	}
+	cases := []struct{ percent, want float64 }{
+		{-10, 0},
+		{50, 50},
+		{150, 100},
+	}
+	for _, tc := range cases {
+		if got := ClampPercent(tc.percent); got != tc.want {
+			t.Errorf("ClampPercent(%v) = %v; want %v", tc.percent, got, tc.want)
+		}
+	}
+	if got := FromPercent(250); got != 2 {
+		t.Errorf("FromPercent(250) = %v; want 2 (integer truncation)", got)
+	}
+}
@@ -0,0 +1,622 @@
+package genmath
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+func GCD[T Integer](a, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return Abs(a)
+}
+
+// GCDSlice folds GCD over vals. The GCD of an empty slice is 0.
+func GCDSlice[T Integer](vals []T) T {
+	gcd := T(0)
+	for _, val := range vals {
+		gcd = GCD(gcd, val)
+	}
+	return gcd
+}
+
+func LCM[T Integer](a, b T) T {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return Abs(a / GCD(a, b) * b)
+}
+
+// CommonDenominator returns the least common multiple of denominators, or 1
+// for an empty slice. It panics if any denominator is zero.
+func CommonDenominator[T Integer](denominators []T) T {
+	common := T(1)
+	for _, den := range denominators {
+		if den == 0 {
+			panic("genmath: zero denominator")
+		}
+		common = LCM(common, den)
+	}
+	return common
+}
+
+// ToCommonDenominator rescales each fraction nums[i]/dens[i] to share the
+// least common denominator. It panics if the slices differ in length or any
+// denominator is zero.
+func ToCommonDenominator[T Integer](nums, dens []T) (commonNums []T, commonDen T) {
+	if len(nums) != len(dens) {
+		panic("genmath: slice length mismatch")
+	}
+	commonDen = CommonDenominator(dens)
+	commonNums = make([]T, len(nums))
+	for i, num := range nums {
+		commonNums[i] = num * (commonDen / dens[i])
+	}
+	return commonNums, commonDen
+}
+
+// SimplifyRatio reduces num/den to lowest terms with any sign carried by the
+// numerator. A zero denominator returns (0, 0). Moving the sign requires
+// negating both terms, so for signed types a negative den paired with a num
+// or den at the type minimum overflows (SimplifyRatio(int8(-128), -1)
+// returns -128/1).
+func SimplifyRatio[T Integer](num, den T) (T, T) {
+	if den == 0 {
+		return 0, 0
+	}
+	if den < 0 {
+		num, den = -num, -den
+	}
+	gcd := GCD(num, den)
+	return num / gcd, den / gcd
+}
+
+// ISqrt returns the floor of the square root of val using integer arithmetic
+// only, so it stays exact where a float64 square root would round. Negative
+// inputs return 0.
+func ISqrt[T Integer](val T) T {
+	root, _ := ISqrtExact(val)
+	return root
+}
+
+// ISqrtExact is ISqrt that also reports whether val is a perfect square.
+// Negative inputs return (0, false).
+func ISqrtExact[T Integer](val T) (T, bool) {
+	if val < 0 {
+		return 0, false
+	}
+	rem := uint64(val)
+	root, bit := uint64(0), uint64(1)<<62
+	for bit > rem {
+		bit >>= 2
+	}
+	for bit != 0 {
+		if rem >= root+bit {
+			rem -= root + bit
+			root = root>>1 + bit
+		} else {
+			root >>= 1
+		}
+		bit >>= 2
+	}
+	return T(root), rem == 0
+}
+
+// AlignUp rounds value up to the nearest multiple of alignment. An alignment
+// of zero or less returns value unchanged.
+func AlignUp[T Integer](value, alignment T) T {
+	if alignment <= 0 {
+		return value
+	}
+	if alignment&(alignment-1) == 0 {
+		return (value + alignment - 1) &^ (alignment - 1)
+	}
+	rem := alignRem(value, alignment)
+	if rem == 0 {
+		return value
+	}
+	return value + alignment - rem
+}
+
+// AlignDown rounds value down to the nearest multiple of alignment. An
+// alignment of zero or less returns value unchanged.
+func AlignDown[T Integer](value, alignment T) T {
+	if alignment <= 0 {
+		return value
+	}
+	if alignment&(alignment-1) == 0 {
+		return value &^ (alignment - 1)
+	}
+	return value - alignRem(value, alignment)
+}
+
+// IsAligned reports whether value is a multiple of alignment. An alignment of
+// zero or less is never satisfied.
+func IsAligned[T Integer](value, alignment T) bool {
+	if alignment <= 0 {
+		return false
+	}
+	return alignRem(value, alignment) == 0
+}
+
+func alignRem[T Integer](value, alignment T) T {
+	rem := value % alignment
+	if rem < 0 {
+		rem += alignment
+	}
+	return rem
+}
+
+// WrapSigned wraps val into the inclusive range [min, max] with modular
+// arithmetic, so max+1 becomes min and min-1 becomes max. Works for signed
+// and unsigned types, including ranges spanning the full width of T.
+func WrapSigned[T Integer](val, min, max T) T {
+	if min > max {
+		min, max = max, min
+	}
+	uVal, uMin, uMax := orderedBits(val), orderedBits(min), orderedBits(max)
+	span := uMax - uMin + 1
+	if span == 0 {
+		return val
+	}
+	var offset uint64
+	if uVal >= uMin {
+		offset = (uVal - uMin) % span
+	} else {
+		offset = (span - (uMin-uVal)%span) % span
+	}
+	return fromOrderedBits[T](uMin + offset)
+}
+
+// orderedBits maps val onto a uint64 such that the ordering of values is
+// preserved, letting signed and unsigned ranges share unsigned arithmetic.
+func orderedBits[T Integer](val T) uint64 {
+	if isSigned[T]() {
+		return uint64(val) ^ 1<<63
+	}
+	return uint64(val)
+}
+
+func fromOrderedBits[T Integer](bits uint64) T {
+	if isSigned[T]() {
+		return T(bits ^ 1<<63)
+	}
+	return T(bits)
+}
+
+// SatAdd returns a + b, saturating at the limits of T instead of wrapping.
+func SatAdd[T Integer](a, b T) T {
+	sum := a + b
+	if b > 0 && sum < a {
+		return maxOf[T]()
+	}
+	if b < 0 && sum > a {
+		return minOf[T]()
+	}
+	return sum
+}
+
+// SatSub returns a - b, saturating at the limits of T instead of wrapping.
+func SatSub[T Integer](a, b T) T {
+	diff := a - b
+	if b > 0 && diff > a {
+		return minOf[T]()
+	}
+	if b < 0 && diff < a {
+		return maxOf[T]()
+	}
+	return diff
+}
+
+// IncSat returns val + 1, staying at the maximum of T instead of wrapping.
+func IncSat[T Integer](val T) T {
+	return SatAdd(val, 1)
+}
+
+// DecSat returns val - 1, staying at the minimum of T instead of wrapping.
+func DecSat[T Integer](val T) T {
+	return SatSub(val, 1)
+}
+
+// Counter is an integer bounded to [Min, Max]. Steps past a bound either stop
+// at it or, if Wrap is set, wrap around to the opposite bound.
+type Counter[T Integer] struct {
+	Value T
+	Min   T
+	Max   T
+	Wrap  bool
+}
+
+// Inc adds one, returning true if the counter was clamped or wrapped.
+func (c *Counter[T]) Inc() bool {
+	return c.step(1, true)
+}
+
+// Dec subtracts one, returning true if the counter was clamped or wrapped.
+func (c *Counter[T]) Dec() bool {
+	return c.step(1, false)
+}
+
+// Add adds n, returning true if the counter was clamped or wrapped.
+func (c *Counter[T]) Add(n T) bool {
+	if n < 0 {
+		return c.step(-uint64(int64(n)), false)
+	}
+	return c.step(uint64(n), true)
+}
+
+func (c *Counter[T]) step(mag uint64, up bool) bool {
+	uMin, uMax := orderedBits(c.Min), orderedBits(c.Max)
+	uVal := Clamp(uMin, orderedBits(c.Value), uMax)
+	var hit bool
+	switch {
+	case c.Wrap && uMax-uMin+1 == 0:
+		if up {
+			hit = mag > uMax-uVal
+			uVal += mag
+		} else {
+			hit = mag > uVal-uMin
+			uVal -= mag
+		}
+	case c.Wrap:
+		span, off := uMax-uMin+1, uVal-uMin
+		step := mag % span
+		if up {
+			hit = mag >= span-off
+			if step >= span-off {
+				off = step - (span - off)
+			} else {
+				off += step
+			}
+		} else {
+			hit = mag > off
+			if step > off {
+				off += span - step
+			} else {
+				off -= step
+			}
+		}
+		uVal = uMin + off
+	case up:
+		hit = mag > uMax-uVal
+		if hit {
+			uVal = uMax
+		} else {
+			uVal += mag
+		}
+	default:
+		hit = mag > uVal-uMin
+		if hit {
+			uVal = uMin
+		} else {
+			uVal -= mag
+		}
+	}
+	c.Value = fromOrderedBits[T](uVal)
+	return hit
+}
+
+// RationalApprox returns the fraction num/den closest to val with
+// 1 <= den <= maxDenominator, found from the continued fraction expansion of
+// val. NaN returns (0, 0) and infinities return (+-1, 0).
+func RationalApprox[T Float](val T, maxDenominator int64) (num, den int64) {
+	fVal := float64(val)
+	switch {
+	case math.IsNaN(fVal):
+		return 0, 0
+	case math.IsInf(fVal, 0):
+		return int64(Sign(fVal)), 0
+	}
+	if maxDenominator < 1 {
+		maxDenominator = 1
+	}
+	exact := new(big.Rat).SetFloat64(math.Abs(fVal))
+	maxDen := big.NewInt(maxDenominator)
+	p0, q0, p1, q1 := big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	n, d := new(big.Int).Set(exact.Num()), new(big.Int).Set(exact.Denom())
+	a, q2, tmp := new(big.Int), new(big.Int), new(big.Int)
+	for d.Sign() != 0 {
+		a.Div(n, d)
+		q2.Add(q0, tmp.Mul(a, q1))
+		if q2.Cmp(maxDen) > 0 {
+			break
+		}
+		p0, p1 = p1, p0.Add(p0, tmp.Mul(a, p1))
+		q0, q1 = q1, q0.Set(q2)
+		n, d = d, n.Sub(n, tmp.Mul(a, d))
+	}
+	if d.Sign() != 0 {
+		k := new(big.Int).Div(tmp.Sub(maxDen, q0), q1)
+		semiNum := new(big.Int).Add(p0, new(big.Int).Mul(k, p1))
+		semiDen := new(big.Int).Add(q0, new(big.Int).Mul(k, q1))
+		semi := new(big.Rat).SetFrac(semiNum, semiDen)
+		conv := new(big.Rat).SetFrac(p1, q1)
+		semiErr := new(big.Rat).Sub(semi, exact)
+		convErr := new(big.Rat).Sub(conv, exact)
+		if semiErr.Abs(semiErr).Cmp(convErr.Abs(convErr)) < 0 {
+			p1, q1 = semiNum, semiDen
+		}
+	}
+	num, den = p1.Int64(), q1.Int64()
+	if fVal < 0 {
+		num = -num
+	}
+	return num, den
+}
+
+// BresenhamLine returns every grid cell on the line from (x0, y0) to (x1, y1),
+// both endpoints included, in order from start to end.
+func BresenhamLine(x0, y0, x1, y1 int) [][2]int {
+	dx, dy := Abs(x1-x0), -Abs(y1-y0)
+	sx, sy := int(Sign(x1-x0)), int(Sign(y1-y0))
+	cells := make([][2]int, 0, Max(dx, -dy)+1)
+	err := dx + dy
+	for {
+		cells = append(cells, [2]int{x0, y0})
+		if x0 == x1 && y0 == y1 {
+			return cells
+		}
+		err2 := 2 * err
+		if err2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if err2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// WrappingDiff returns a - b modulo the width of T, which is the true distance
+// from b forward to a as long as the counter wrapped at most once.
+func WrappingDiff[T Unsigned](a, b T) T {
+	return a - b
+}
+
+// WrappingLess reports whether a comes before b on a wrapping counter, using
+// the half-range convention of serial number arithmetic: a is less than b when
+// b is ahead of it by less than half the range of T.
+func WrappingLess[T Unsigned](a, b T) bool {
+	ahead := b - a
+	return ahead != 0 && ahead < T(1)<<(bitsOf[T]()-1)
+}
+
+// FlattenIndex converts indices into a row-major offset within an array of
+// shape dims. It panics if the slices differ in length, any dimension is not
+// positive, or any index is outside its dimension.
+func FlattenIndex[T Integer](indices, dims []T) T {
+	if len(indices) != len(dims) {
+		panic("genmath: slice length mismatch")
+	}
+	checkDims(dims)
+	offset := T(0)
+	for i, idx := range indices {
+		if idx < 0 || idx >= dims[i] {
+			panic("genmath: FlattenIndex index out of range")
+		}
+		offset = offset*dims[i] + idx
+	}
+	return offset
+}
+
+// UnflattenIndex converts a row-major offset back into per-dimension indices
+// for an array of shape dims. It panics if any dimension is not positive or
+// offset is outside the array.
+func UnflattenIndex[T Integer](offset T, dims []T) []T {
+	checkDims(dims)
+	indices := make([]T, len(dims))
+	rem := offset
+	for i := len(dims) - 1; i >= 0; i -= 1 {
+		indices[i] = rem % dims[i]
+		rem /= dims[i]
+	}
+	if offset < 0 || rem != 0 {
+		panic("genmath: UnflattenIndex offset out of range")
+	}
+	return indices
+}
+
+func checkDims[T Integer](dims []T) {
+	for _, dim := range dims {
+		if dim <= 0 {
+			panic("genmath: dimensions must be positive")
+		}
+	}
+}
+
+// AspectRatio reduces width:height to lowest terms, so 1920x1080 gives 16:9.
+// A zero dimension returns (0, 0).
+func AspectRatio[T Integer](width, height T) (T, T) {
+	if width == 0 || height == 0 {
+		return 0, 0
+	}
+	gcd := GCD(width, height)
+	return width / gcd, height / gcd
+}
+
+type standardAspect struct {
+	name  string
+	ratio float64
+}
+
+var standardAspects = []standardAspect{
+	{"1:1", 1},
+	{"5:4", 5.0 / 4},
+	{"4:3", 4.0 / 3},
+	{"3:2", 3.0 / 2},
+	{"16:10", 16.0 / 10},
+	{"16:9", 16.0 / 9},
+	{"21:9", 21.0 / 9},
+	{"32:9", 32.0 / 9},
+}
+
+// NearestStandardAspect returns the name of the common aspect ratio within 2%
+// of width:height (such as "16:9"), or "" if none is that close or either
+// dimension is not positive.
+func NearestStandardAspect(width, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	ratio := float64(width) / float64(height)
+	relErr := func(a standardAspect) float64 { return math.Abs(ratio/a.ratio - 1) }
+	aspect, _ := ArgMin(standardAspects, relErr)
+	if relErr(aspect) > 0.02 {
+		return ""
+	}
+	return aspect.name
+}
+
+var (
+	ErrBitWidthOverflow = errors.New("genmath: bit widths exceed the size of the packed type")
+	ErrBitValueOverflow = errors.New("genmath: value does not fit its bit width")
+)
+
+// PackBits packs values into one integer, least significant field first, with
+// each value occupying the matching number of bits in widths. It panics if
+// the slices differ in length.
+func PackBits[T Unsigned](values []T, widths []int) (T, error) {
+	if len(values) != len(widths) {
+		panic("genmath: slice length mismatch")
+	}
+	if err := checkBitWidths[T](widths); err != nil {
+		return 0, err
+	}
+	packed, shift := T(0), 0
+	for i, val := range values {
+		if widths[i] < bitsOf[T]() && val>>widths[i] != 0 {
+			return 0, ErrBitValueOverflow
+		}
+		packed |= val << shift
+		shift += widths[i]
+	}
+	return packed, nil
+}
+
+// UnpackBits is the inverse of PackBits, splitting packed into one value per
+// entry in widths.
+func UnpackBits[T Unsigned](packed T, widths []int) ([]T, error) {
+	if err := checkBitWidths[T](widths); err != nil {
+		return nil, err
+	}
+	values := make([]T, len(widths))
+	for i, width := range widths {
+		mask := ^T(0)
+		if width < bitsOf[T]() {
+			mask = T(1)<<width - 1
+		}
+		values[i] = packed & mask
+		packed >>= width
+	}
+	return values, nil
+}
+
+func checkBitWidths[T Unsigned](widths []int) error {
+	total := 0
+	for _, width := range widths {
+		if width < 0 {
+			return ErrBitWidthOverflow
+		}
+		total += width
+	}
+	if total > bitsOf[T]() {
+		return ErrBitWidthOverflow
+	}
+	return nil
+}
+
+// absDiff returns |a - b| without negating, so it never underflows an unsigned
+// type or hits the signed minimum.
+func absDiff[T Integer](a, b T) T {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func ManhattanDistance[T Integer](x0, y0, x1, y1 T) T {
+	return absDiff(x0, x1) + absDiff(y0, y1)
+}
+
+func ChebyshevDistance[T Integer](x0, y0, x1, y1 T) T {
+	return Max(absDiff(x0, x1), absDiff(y0, y1))
+}
+
+// NextPowerOfTwo returns the smallest power of two >= val, 1 for val <= 1,
+// or 0 if that power does not fit in T.
+func NextPowerOfTwo[T Integer](val T) T {
+	if val <= 1 {
+		return 1
+	}
+	exp := bits.Len64(uint64(val - 1))
+	if exp >= bits.Len64(uint64(maxOf[T]())) {
+		return 0
+	}
+	return T(1) << exp
+}
+
+// PrevPowerOfTwo returns the largest power of two <= val, or 0 for val <= 0.
+func PrevPowerOfTwo[T Integer](val T) T {
+	if val <= 0 {
+		return 0
+	}
+	return T(1) << (bits.Len64(uint64(val)) - 1)
+}
+
+// NearestPowerOfTwo returns whichever power of two adjacent to val is closer
+// in log space, so the split between p and 2p falls at p*SQRT_2. If 2p does
+// not fit in T the result is p. Returns 0 for val <= 0.
+func NearestPowerOfTwo[T Integer](val T) T {
+	prev := PrevPowerOfTwo(val)
+	if prev == 0 || prev == val || prev > maxOf[T]()/2 {
+		return prev
+	}
+	if float64(val) < float64(prev)*SQRT_2 {
+		return prev
+	}
+	return prev * 2
+}
+
+// PowMod returns base^exp mod m by binary exponentiation. Products are
+// reduced through 128 bits, so every value of every Integer type is
+// supported. A negative base gives a result in [0, m). It panics if m <= 0
+// or exp < 0.
+func PowMod[T Integer](base, exp, mod T) T {
+	if mod <= 0 || exp < 0 {
+		panic("genmath: PowMod requires mod > 0 and exp >= 0")
+	}
+	m := uint64(mod)
+	var b uint64
+	if base < 0 {
+		b = m - uint64(-(int64(base)%int64(mod)))%m
+	} else {
+		b = uint64(base) % m
+	}
+	result := 1 % m
+	for e := uint64(exp); e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mulMod(result, b, m)
+		}
+		b = mulMod(b, b, m)
+	}
+	return T(result)
+}
+
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// Midpoint returns the average of a and b rounded toward negative infinity,
+// without the overflow of (a+b)/2 near the limits of T.
+func Midpoint[T Integer](a, b T) T {
+	return (a & b) + (a^b)>>1
+}
+
+// MidpointF returns (a+b)/2, the float counterpart of Midpoint.
+func MidpointF[T Float](a, b T) T {
+	return (a + b) / 2
+}
@@ -0,0 +1,451 @@
+package genmath
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSimplifyRatio(t *testing.T) {
+	cases := []struct{ num, den, wantNum, wantDen int }{
+		{6, 8, 3, 4},
+		{-4, -2, 2, 1},
+		{4, -6, -2, 3},
+		{0, 5, 0, 1},
+		{7, 0, 0, 0},
+	}
+	for _, tc := range cases {
+		if num, den := SimplifyRatio(tc.num, tc.den); num != tc.wantNum || den != tc.wantDen {
+			t.Errorf("SimplifyRatio(%d, %d) = %d/%d; want %d/%d", tc.num, tc.den, num, den, tc.wantNum, tc.wantDen)
+		}
+	}
+}
+
+func TestISqrt(t *testing.T) {
+	for _, root := range []uint64{0, 1, 2, 7, 1000, 4294967295} {
+		square := root * root
+		if got, exact := ISqrtExact(square); got != root || !exact {
+			t.Errorf("ISqrtExact(%d) = %d, %v; want %d, true", square, got, exact, root)
+		}
+		if root < 2 {
+			continue
+		}
+		if got, exact := ISqrtExact(square - 1); got != root-1 || exact {
+			t.Errorf("ISqrtExact(%d) = %d, %v; want %d, false", square-1, got, exact, root-1)
+		}
+		if got, exact := ISqrtExact(square + 1); got != root || exact {
+			t.Errorf("ISqrtExact(%d) = %d, %v; want %d, false", square+1, got, exact, root)
+		}
+	}
+	if got := ISqrt(uint64(MAX_U64)); got != 4294967295 {
+		t.Errorf("ISqrt(MAX_U64) = %d; want 4294967295", got)
+	}
+	if got := ISqrt(int64(MAX_I64)); got != 3037000499 {
+		t.Errorf("ISqrt(MAX_I64) = %d; want 3037000499", got)
+	}
+	if got, exact := ISqrtExact(-4); got != 0 || exact {
+		t.Errorf("ISqrtExact(-4) = %d, %v; want 0, false", got, exact)
+	}
+}
+
+func TestAlign(t *testing.T) {
+	cases := []struct{ value, alignment, up, down int }{
+		{13, 8, 16, 8},
+		{16, 8, 16, 16},
+		{13, 6, 18, 12},
+		{12, 6, 12, 12},
+		{-13, 8, -8, -16},
+		{13, 0, 13, 13},
+	}
+	for _, tc := range cases {
+		if got := AlignUp(tc.value, tc.alignment); got != tc.up {
+			t.Errorf("AlignUp(%d, %d) = %d; want %d", tc.value, tc.alignment, got, tc.up)
+		}
+		if got := AlignDown(tc.value, tc.alignment); got != tc.down {
+			t.Errorf("AlignDown(%d, %d) = %d; want %d", tc.value, tc.alignment, got, tc.down)
+		}
+	}
+	if !IsAligned(16, 8) || !IsAligned(12, 6) || IsAligned(13, 8) || IsAligned(8, 0) {
+		t.Errorf("IsAligned returned the wrong result")
+	}
+}
+
+func TestWrapSigned(t *testing.T) {
+	cases := []struct{ val, want int }{
+		{0, 0},
+		{10, 10},
+		{-10, -10},
+		{11, -10},
+		{-11, 10},
+		{31, 10},
+		{-32, 10},
+		{42, 0},
+	}
+	for _, tc := range cases {
+		if got := WrapSigned(tc.val, -10, 10); got != tc.want {
+			t.Errorf("WrapSigned(%d, -10, 10) = %d; want %d", tc.val, got, tc.want)
+		}
+	}
+	if got := WrapSigned(int8(MIN_I8), MIN_I8, MAX_I8); got != MIN_I8 {
+		t.Errorf("WrapSigned over the full int8 range = %d; want %d", got, MIN_I8)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	clamp := Counter[int]{Value: 8, Min: 0, Max: 9}
+	if hit := clamp.Inc(); hit || clamp.Value != 9 {
+		t.Errorf("clamp Inc to Max = %d, %v; want 9, false", clamp.Value, hit)
+	}
+	if hit := clamp.Inc(); !hit || clamp.Value != 9 {
+		t.Errorf("clamp Inc past Max = %d, %v; want 9, true", clamp.Value, hit)
+	}
+	if hit := clamp.Add(-20); !hit || clamp.Value != 0 {
+		t.Errorf("clamp Add(-20) = %d, %v; want 0, true", clamp.Value, hit)
+	}
+
+	wrap := Counter[int]{Value: 9, Min: 0, Max: 9, Wrap: true}
+	if hit := wrap.Inc(); !hit || wrap.Value != 0 {
+		t.Errorf("wrap Inc past Max = %d, %v; want 0, true", wrap.Value, hit)
+	}
+	if hit := wrap.Dec(); !hit || wrap.Value != 9 {
+		t.Errorf("wrap Dec past Min = %d, %v; want 9, true", wrap.Value, hit)
+	}
+	if hit := wrap.Add(23); !hit || wrap.Value != 2 {
+		t.Errorf("wrap Add(23) = %d, %v; want 2, true", wrap.Value, hit)
+	}
+
+	full := Counter[uint8]{Value: 255, Min: 0, Max: 255, Wrap: true}
+	if hit := full.Inc(); !hit || full.Value != 0 {
+		t.Errorf("full-range uint8 Inc = %d, %v; want 0, true", full.Value, hit)
+	}
+}
+
+func TestRationalApprox(t *testing.T) {
+	cases := []struct {
+		val            float64
+		maxDen         int64
+		wantNum, wantD int64
+	}{
+		{PI, 100, 311, 99},
+		{PI, 1000, 355, 113},
+		{PI, 7, 22, 7},
+		{PI, 1, 3, 1},
+		{0.25, 100, 1, 4},
+		{-0.25, 100, -1, 4},
+		{0.333, 10, 1, 3},
+	}
+	for _, tc := range cases {
+		num, den := RationalApprox(tc.val, tc.maxDen)
+		if num != tc.wantNum || den != tc.wantD {
+			t.Errorf("RationalApprox(%v, %d) = %d/%d; want %d/%d", tc.val, tc.maxDen, num, den, tc.wantNum, tc.wantD)
+		}
+		if den > tc.maxDen {
+			t.Errorf("RationalApprox(%v, %d) denominator %d exceeds the cap", tc.val, tc.maxDen, den)
+		}
+	}
+}
+
+func TestBresenhamLine(t *testing.T) {
+	cases := []struct {
+		name           string
+		x0, y0, x1, y1 int
+		want           [][2]int
+	}{
+		{"horizontal", 0, 0, 3, 0, [][2]int{{0, 0}, {1, 0}, {2, 0}, {3, 0}}},
+		{"vertical", 0, 0, 0, -3, [][2]int{{0, 0}, {0, -1}, {0, -2}, {0, -3}}},
+		{"diagonal", 0, 0, 3, 3, [][2]int{{0, 0}, {1, 1}, {2, 2}, {3, 3}}},
+		{"shallow", 0, 0, 4, 1, [][2]int{{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 1}}},
+		{"steep", 0, 0, 1, 4, [][2]int{{0, 0}, {0, 1}, {1, 2}, {1, 3}, {1, 4}}},
+		{"point", 2, 2, 2, 2, [][2]int{{2, 2}}},
+	}
+	for _, tc := range cases {
+		got := BresenhamLine(tc.x0, tc.y0, tc.x1, tc.y1)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: BresenhamLine = %v; want %v", tc.name, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: BresenhamLine = %v; want %v", tc.name, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestWrappingDiff(t *testing.T) {
+	if got := WrappingDiff(uint8(5), 250); got != 11 {
+		t.Errorf("WrappingDiff(uint8(5), 250) = %d; want 11", got)
+	}
+	if got := WrappingDiff(uint32(3), MAX_U32); got != 4 {
+		t.Errorf("WrappingDiff(uint32(3), MAX_U32) = %d; want 4", got)
+	}
+	if got := WrappingDiff(uint16(100), 40); got != 60 {
+		t.Errorf("WrappingDiff(uint16(100), 40) = %d; want 60", got)
+	}
+}
+
+func TestWrappingLess(t *testing.T) {
+	cases := []struct {
+		a, b uint8
+		want bool
+	}{
+		{250, 5, true},
+		{5, 250, false},
+		{10, 20, true},
+		{20, 10, false},
+		{7, 7, false},
+		{0, 127, true},
+		{0, 128, false},
+	}
+	for _, tc := range cases {
+		if got := WrappingLess(tc.a, tc.b); got != tc.want {
+			t.Errorf("WrappingLess(%d, %d) = %v; want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestFlattenIndexRoundTrip(t *testing.T) {
+	dims := []int{3, 4, 5}
+	for offset := 0; offset < 60; offset += 1 {
+		indices := UnflattenIndex(offset, dims)
+		if got := FlattenIndex(indices, dims); got != offset {
+			t.Fatalf("FlattenIndex(UnflattenIndex(%d)) = %d via %v", offset, got, indices)
+		}
+	}
+	if got := FlattenIndex([]int{2, 3, 4}, dims); got != 59 {
+		t.Errorf("FlattenIndex([2 3 4], [3 4 5]) = %d; want 59", got)
+	}
+	if got := UnflattenIndex(27, dims); got[0] != 1 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("UnflattenIndex(27, [3 4 5]) = %v; want [1 1 2]", got)
+	}
+}
+
+func TestFlattenIndexZeroDim(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"FlattenIndex":   func() { FlattenIndex([]int{0, 0}, []int{3, 0}) },
+		"UnflattenIndex": func() { UnflattenIndex(0, []int{3, 0}) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != "genmath: dimensions must be positive" {
+					t.Errorf("%s with a zero dimension panicked with %v", name, r)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestAspectRatio(t *testing.T) {
+	cases := []struct{ w, h, wantW, wantH int }{
+		{1920, 1080, 16, 9},
+		{1280, 800, 8, 5},
+		{1366, 768, 683, 384},
+		{0, 768, 0, 0},
+	}
+	for _, tc := range cases {
+		if w, h := AspectRatio(tc.w, tc.h); w != tc.wantW || h != tc.wantH {
+			t.Errorf("AspectRatio(%d, %d) = %d:%d; want %d:%d", tc.w, tc.h, w, h, tc.wantW, tc.wantH)
+		}
+	}
+}
+
+func TestNearestStandardAspect(t *testing.T) {
+	cases := []struct {
+		w, h int
+		want string
+	}{
+		{1920, 1080, "16:9"},
+		{1280, 800, "16:10"},
+		{1366, 768, "16:9"},
+		{2560, 1080, "21:9"},
+		{1000, 300, ""},
+		{1920, 0, ""},
+	}
+	for _, tc := range cases {
+		if got := NearestStandardAspect(tc.w, tc.h); got != tc.want {
+			t.Errorf("NearestStandardAspect(%d, %d) = %q; want %q", tc.w, tc.h, got, tc.want)
+		}
+	}
+}
+
+func TestPackBitsRoundTrip(t *testing.T) {
+	widths := []int{5, 6, 5}
+	values := []uint16{31, 42, 7}
+	packed, err := PackBits(values, widths)
+	if err != nil || packed != 31|42<<5|7<<11 {
+		t.Fatalf("PackBits(%v, %v) = %#x, %v", values, widths, packed, err)
+	}
+	unpacked, err := UnpackBits(packed, widths)
+	if err != nil {
+		t.Fatalf("UnpackBits error: %v", err)
+	}
+	for i := range values {
+		if unpacked[i] != values[i] {
+			t.Errorf("UnpackBits = %v; want %v", unpacked, values)
+			break
+		}
+	}
+	full, err := PackBits([]uint8{0xAB}, []int{8})
+	if err != nil || full != 0xAB {
+		t.Errorf("PackBits full width = %#x, %v; want 0xab, nil", full, err)
+	}
+}
+
+func TestPackBitsErrors(t *testing.T) {
+	if _, err := PackBits([]uint16{32, 0}, []int{5, 6}); !errors.Is(err, ErrBitValueOverflow) {
+		t.Errorf("PackBits(value too wide) error = %v; want ErrBitValueOverflow", err)
+	}
+	if _, err := PackBits([]uint8{1, 1}, []int{5, 4}); !errors.Is(err, ErrBitWidthOverflow) {
+		t.Errorf("PackBits(widths over 8 bits) error = %v; want ErrBitWidthOverflow", err)
+	}
+	if _, err := UnpackBits(uint8(0), []int{3, -1}); !errors.Is(err, ErrBitWidthOverflow) {
+		t.Errorf("UnpackBits(negative width) error = %v; want ErrBitWidthOverflow", err)
+	}
+}
+
+func TestGridDistances(t *testing.T) {
+	cases := []struct {
+		name                       string
+		x0, y0, x1, y1, manh, cheb int
+	}{
+		{"diagonal", 0, 0, 3, 3, 6, 3},
+		{"horizontal", -2, 5, 4, 5, 6, 6},
+		{"vertical", 1, 7, 1, -3, 10, 10},
+		{"mixed", 0, 0, -2, 5, 7, 5},
+		{"same", 4, 4, 4, 4, 0, 0},
+	}
+	for _, tc := range cases {
+		if got := ManhattanDistance(tc.x0, tc.y0, tc.x1, tc.y1); got != tc.manh {
+			t.Errorf("%s: ManhattanDistance = %d; want %d", tc.name, got, tc.manh)
+		}
+		if got := ChebyshevDistance(tc.x0, tc.y0, tc.x1, tc.y1); got != tc.cheb {
+			t.Errorf("%s: ChebyshevDistance = %d; want %d", tc.name, got, tc.cheb)
+		}
+	}
+	if got := ManhattanDistance(uint8(10), 0, 0, 10); got != 20 {
+		t.Errorf("ManhattanDistance(uint8) = %d; want 20", got)
+	}
+}
+
+func TestIncDecSat(t *testing.T) {
+	if got := IncSat(int8(MAX_I8)); got != MAX_I8 {
+		t.Errorf("IncSat(MAX_I8) = %d; want MAX_I8", got)
+	}
+	if got := IncSat(int8(MAX_I8 - 1)); got != MAX_I8 {
+		t.Errorf("IncSat(MAX_I8-1) = %d; want MAX_I8", got)
+	}
+	if got := DecSat(int8(MIN_I8)); got != MIN_I8 {
+		t.Errorf("DecSat(MIN_I8) = %d; want MIN_I8", got)
+	}
+	if got := IncSat(uint8(MAX_U8)); got != MAX_U8 {
+		t.Errorf("IncSat(MAX_U8) = %d; want MAX_U8", got)
+	}
+	if got := DecSat(uint8(0)); got != 0 {
+		t.Errorf("DecSat(uint8(0)) = %d; want 0", got)
+	}
+	if got := DecSat(uint8(1)); got != 0 {
+		t.Errorf("DecSat(uint8(1)) = %d; want 0", got)
+	}
+}
+
+func TestPowerOfTwo(t *testing.T) {
+	cases := []struct{ val, next, prev, nearest int }{
+		{0, 1, 0, 0},
+		{1, 1, 1, 1},
+		{16, 16, 16, 16},
+		{17, 32, 16, 16},
+		{23, 32, 16, 32},
+		{15, 16, 8, 16},
+		{-4, 1, 0, 0},
+	}
+	for _, tc := range cases {
+		if got := NextPowerOfTwo(tc.val); got != tc.next {
+			t.Errorf("NextPowerOfTwo(%d) = %d; want %d", tc.val, got, tc.next)
+		}
+		if got := PrevPowerOfTwo(tc.val); got != tc.prev {
+			t.Errorf("PrevPowerOfTwo(%d) = %d; want %d", tc.val, got, tc.prev)
+		}
+		if got := NearestPowerOfTwo(tc.val); got != tc.nearest {
+			t.Errorf("NearestPowerOfTwo(%d) = %d; want %d", tc.val, got, tc.nearest)
+		}
+	}
+	if got := NextPowerOfTwo(uint8(129)); got != 0 {
+		t.Errorf("NextPowerOfTwo(uint8(129)) = %d; want 0 (overflow)", got)
+	}
+	if got := NearestPowerOfTwo(uint8(250)); got != 128 {
+		t.Errorf("NearestPowerOfTwo(uint8(250)) = %d; want 128", got)
+	}
+}
+
+func TestCommonDenominator(t *testing.T) {
+	if got := CommonDenominator([]int{2, 3, 6}); got != 6 {
+		t.Errorf("CommonDenominator(2, 3, 6) = %d; want 6", got)
+	}
+	if got := CommonDenominator([]int{}); got != 1 {
+		t.Errorf("CommonDenominator(empty) = %d; want 1", got)
+	}
+	nums, den := ToCommonDenominator([]int{1, 1, 1}, []int{2, 3, 6})
+	if den != 6 || nums[0] != 3 || nums[1] != 2 || nums[2] != 1 {
+		t.Errorf("ToCommonDenominator(1/2, 1/3, 1/6) = %v/%d; want [3 2 1]/6", nums, den)
+	}
+}
+
+func TestPowMod(t *testing.T) {
+	cases := []struct{ base, exp, mod, want int64 }{
+		{2, 10, 1000, 24},
+		{3, 4, 5, 1},
+		{7, 0, 13, 1},
+		{7, 0, 1, 0},
+		{5, 3, 1, 0},
+		{-2, 3, 5, 2},
+		{4, 13, 497, 445},
+	}
+	for _, tc := range cases {
+		if got := PowMod(tc.base, tc.exp, tc.mod); got != tc.want {
+			t.Errorf("PowMod(%d, %d, %d) = %d; want %d", tc.base, tc.exp, tc.mod, got, tc.want)
+		}
+	}
+	// Both the power and the intermediate products overflow 64 bits.
+	if got := PowMod(uint64(1<<63+5), 1<<62, uint64(MAX_U64-58)); got != 15412845760647036012 {
+		t.Errorf("PowMod(2^63+5, 2^62, 2^64-59) = %d; want 15412845760647036012", got)
+	}
+	if got := PowMod(int8(100), 100, 127); got != 25 {
+		t.Errorf("PowMod(int8(100), 100, 127) = %d; want 25", got)
+	}
+}
+
+func TestPowModPanics(t *testing.T) {
+	for _, args := range [][3]int{{2, 3, 0}, {2, -1, 5}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("PowMod%v did not panic", args)
+				}
+			}()
+			PowMod(args[0], args[1], args[2])
+		}()
+	}
+}
+
+func TestMidpoint(t *testing.T) {
+	cases := []struct{ a, b, want int64 }{
+		{MAX_I64, MAX_I64 - 2, MAX_I64 - 1},
+		{MAX_I64, MAX_I64, MAX_I64},
+		{MIN_I64, MAX_I64, -1},
+		{-3, 6, 1},
+		{6, -3, 1},
+		{-7, -4, -6},
+	}
+	for _, tc := range cases {
+		if got := Midpoint(tc.a, tc.b); got != tc.want {
+			t.Errorf("Midpoint(%d, %d) = %d; want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+	if got := Midpoint(uint8(255), 253); got != 254 {
+		t.Errorf("Midpoint(uint8(255), 253) = %d; want 254", got)
+	}
+	if got := MidpointF(1.0, 2); got != 1.5 {
+		t.Errorf("MidpointF(1, 2) = %v; want 1.5", got)
+	}
+}
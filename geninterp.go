@@ -0,0 +1,150 @@
+package genmath
+
+import "math"
+
+func CosineInterp[T Real](start T, end T, amount float64) T {
+	amount = Clamp(0, amount, 1)
+	cosAmount := (1 - math.Cos(amount*PI)) / 2
+	return Lerp(start, end, cosAmount)
+}
+
+func CatmullRom[T Real](p0, p1, p2, p3 T, t float64) T {
+	f0, f1, f2, f3 := float64(p0), float64(p1), float64(p2), float64(p3)
+	t2 := t * t
+	t3 := t2 * t
+	fSpline := 0.5 * (2*f1 + (f2-f0)*t + (2*f0-5*f1+4*f2-f3)*t2 + (3*f1-f0-3*f2+f3)*t3)
+	return T(fSpline)
+}
+
+// KochanekBartels evaluates the segment from p1 to p2 of a TCB spline, the
+// Hermite curve whose tangents at p1 and p2 are shaped by tension,
+// continuity and bias (each usually in [-1, 1]). With all three at 0 it is
+// identical to CatmullRom. Positive tension tightens the curve, continuity
+// trades a smooth corner for a sharp one and bias leans the tangents toward
+// the incoming (positive) or outgoing (negative) segment.
+func KochanekBartels[T Real](p0, p1, p2, p3 T, t, tension, continuity, bias float64) T {
+	f0, f1, f2, f3 := float64(p0), float64(p1), float64(p2), float64(p3)
+	ten := 1 - tension
+	out1 := ten*(1+continuity)*(1+bias)/2*(f1-f0) + ten*(1-continuity)*(1-bias)/2*(f2-f1)
+	in2 := ten*(1-continuity)*(1+bias)/2*(f2-f1) + ten*(1+continuity)*(1-bias)/2*(f3-f2)
+	t2 := t * t
+	t3 := t2 * t
+	fSpline := (2*t3-3*t2+1)*f1 + (t3-2*t2+t)*out1 + (3*t2-2*t3)*f2 + (t3-t2)*in2
+	return T(fSpline)
+}
+
+// SplineCatmullRom samples a Catmull-Rom spline passing through every point,
+// with t in [0, 1] spanning the whole curve and each segment taking an equal
+// share of t. The first and last points are duplicated as outer controls. A
+// NaN t returns the first point.
+func SplineCatmullRom[T Real](points []T, t float64) T {
+	if len(points) == 0 {
+		return 0
+	}
+	if len(points) == 1 || t != t {
+		return points[0]
+	}
+	last := len(points) - 1
+	pos := Clamp(0, t, 1) * float64(last)
+	seg := int(pos)
+	if seg >= last {
+		seg = last - 1
+	}
+	local := pos - float64(seg)
+	p0, p3 := points[seg], points[seg+1]
+	if seg > 0 {
+		p0 = points[seg-1]
+	}
+	if seg+2 <= last {
+		p3 = points[seg+2]
+	}
+	return CatmullRom(p0, points[seg], points[seg+1], p3, local)
+}
+
+func EaseInQuad(t float64) float64 {
+	t = Clamp(0, t, 1)
+	return t * t
+}
+
+func EaseOutQuad(t float64) float64 {
+	t = Clamp(0, t, 1)
+	return 1 - (1-t)*(1-t)
+}
+
+func EaseInOutQuad(t float64) float64 {
+	t = Clamp(0, t, 1)
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - 2*(1-t)*(1-t)
+}
+
+func EaseInCubic(t float64) float64 {
+	t = Clamp(0, t, 1)
+	return t * t * t
+}
+
+func EaseOutCubic(t float64) float64 {
+	t = Clamp(0, t, 1)
+	return 1 - (1-t)*(1-t)*(1-t)
+}
+
+func EaseInOutCubic(t float64) float64 {
+	t = Clamp(0, t, 1)
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - 4*(1-t)*(1-t)*(1-t)
+}
+
+func SmoothStep[T Real](start T, end T, amount float64) T {
+	amount = Clamp(0, amount, 1)
+	return Lerp(start, end, amount*amount*(3-2*amount))
+}
+
+func SmootherStep[T Real](start T, end T, amount float64) T {
+	amount = Clamp(0, amount, 1)
+	return Lerp(start, end, amount*amount*amount*(amount*(amount*6-15)+10))
+}
+
+type InterpMode uint8
+
+const (
+	InterpLinear InterpMode = iota
+	InterpCosine
+	InterpSmoothStep
+	InterpSmootherStep
+)
+
+// Interpolate blends from start to end using the curve selected by mode.
+// Linear extrapolates outside [0, 1]; the other modes clamp amount.
+func Interpolate[T Real](start T, end T, amount float64, mode InterpMode) T {
+	switch mode {
+	case InterpCosine:
+		return CosineInterp(start, end, amount)
+	case InterpSmoothStep:
+		return SmoothStep(start, end, amount)
+	case InterpSmootherStep:
+		return SmootherStep(start, end, amount)
+	default:
+		return Lerp(start, end, amount)
+	}
+}
+
+// TileBlend returns the weight for cross-fading a tiling pattern with its copy
+// shifted back by one tile. Sampling at the in-tile coordinate,
+//
+//	x := WrapPhaseRange(val, tileSize)
+//	Lerp(sample(x), sample(x-tileSize), TileBlend(val, tileSize))
+//
+// repeats every tileSize without a visible seam; sampling at the unwrapped
+// val does not. The weight is 0 over the first three quarters of each tile and
+// eases from 0 to 1 with SmoothStep across the last quarter, the blend region,
+// reaching 1 at the seam. A tileSize of 0 or less returns 0.
+func TileBlend[T Float](val, tileSize T) T {
+	if tileSize <= 0 {
+		return 0
+	}
+	pos := float64(WrapPhaseRange(val, tileSize) / tileSize)
+	return T(SmoothStep(0.0, 1, (pos-0.75)*4))
+}
@@ -0,0 +1,163 @@
+package genmath
+
+import (
+	"testing"
+)
+
+func TestCosineInterp(t *testing.T) {
+	cases := []struct{ amount, want float64 }{
+		{0, 10},
+		{1, 20},
+		{0.5, 15},
+		{-1, 10},
+		{2, 20},
+	}
+	for _, tc := range cases {
+		if got := CosineInterp(10.0, 20, tc.amount); !approxEqual(got, tc.want, 1e-12) {
+			t.Errorf("CosineInterp(10, 20, %v) = %v; want %v", tc.amount, got, tc.want)
+		}
+	}
+	if got := CosineInterp(10.0, 20, 0.25); !(got > 10 && got < Lerp(10.0, 20, 0.25)) {
+		t.Errorf("CosineInterp(10, 20, 0.25) = %v; want between 10 and the linear 12.5", got)
+	}
+}
+
+func TestSplineCatmullRomBoundaries(t *testing.T) {
+	points := []float64{0, 4, 2, 7, 5}
+	last := len(points) - 1
+	for i, want := range points {
+		tt := float64(i) / float64(last)
+		if got := SplineCatmullRom(points, tt); !approxEqual(got, want, 1e-12) {
+			t.Errorf("SplineCatmullRom(points, %v) = %v; want control point %v", tt, got, want)
+		}
+	}
+	if got := SplineCatmullRom(points, -1); got != points[0] {
+		t.Errorf("SplineCatmullRom(points, -1) = %v; want %v", got, points[0])
+	}
+	if got := SplineCatmullRom(points, 2); got != points[last] {
+		t.Errorf("SplineCatmullRom(points, 2) = %v; want %v", got, points[last])
+	}
+	if got := SplineCatmullRom(points, QNaN64()); got != points[0] {
+		t.Errorf("SplineCatmullRom(points, NaN) = %v; want %v", got, points[0])
+	}
+}
+
+func TestEase(t *testing.T) {
+	cases := []struct {
+		name string
+		ease func(float64) float64
+		mid  float64
+	}{
+		{"EaseInQuad", EaseInQuad, 0.25},
+		{"EaseOutQuad", EaseOutQuad, 0.75},
+		{"EaseInOutQuad", EaseInOutQuad, 0.5},
+		{"EaseInCubic", EaseInCubic, 0.125},
+		{"EaseOutCubic", EaseOutCubic, 0.875},
+		{"EaseInOutCubic", EaseInOutCubic, 0.5},
+	}
+	for _, tc := range cases {
+		if got := tc.ease(0); got != 0 {
+			t.Errorf("%s(0) = %v; want 0", tc.name, got)
+		}
+		if got := tc.ease(1); got != 1 {
+			t.Errorf("%s(1) = %v; want 1", tc.name, got)
+		}
+		if got := tc.ease(0.5); !approxEqual(got, tc.mid, 1e-12) {
+			t.Errorf("%s(0.5) = %v; want %v", tc.name, got, tc.mid)
+		}
+		if got := tc.ease(2); got != 1 {
+			t.Errorf("%s(2) = %v; want 1 (clamped)", tc.name, got)
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	modes := []struct {
+		mode    InterpMode
+		quarter float64
+	}{
+		{InterpLinear, 2.5},
+		{InterpCosine, CosineInterp(0.0, 10, 0.25)},
+		{InterpSmoothStep, 1.5625},
+		{InterpSmootherStep, 1.03515625},
+	}
+	for _, tc := range modes {
+		if got := Interpolate(0.0, 10, 0, tc.mode); got != 0 {
+			t.Errorf("Interpolate(mode %d, 0) = %v; want 0", tc.mode, got)
+		}
+		if got := Interpolate(0.0, 10, 1, tc.mode); got != 10 {
+			t.Errorf("Interpolate(mode %d, 1) = %v; want 10", tc.mode, got)
+		}
+		if got := Interpolate(0.0, 10, 0.5, tc.mode); !approxEqual(got, 5, 1e-12) {
+			t.Errorf("Interpolate(mode %d, 0.5) = %v; want 5", tc.mode, got)
+		}
+		if got := Interpolate(0.0, 10, 0.25, tc.mode); !approxEqual(got, tc.quarter, 1e-12) {
+			t.Errorf("Interpolate(mode %d, 0.25) = %v; want %v", tc.mode, got, tc.quarter)
+		}
+	}
+}
+
+func TestKochanekBartels(t *testing.T) {
+	for _, tt := range []float64{0, 0.1, 0.3, 0.5, 0.7, 0.9, 1} {
+		got := KochanekBartels(1.0, 4, 2, 7, tt, 0, 0, 0)
+		if want := CatmullRom(1.0, 4, 2, 7, tt); !approxEqual(got, want, 1e-12) {
+			t.Errorf("KochanekBartels(t=%v, zero TCB) = %v; want CatmullRom %v", tt, got, want)
+		}
+	}
+	for _, tcb := range [][3]float64{{0.5, -0.3, 0.8}, {-1, 1, -1}, {1, 0, 0}} {
+		if got := KochanekBartels(1.0, 4, 2, 7, 0, tcb[0], tcb[1], tcb[2]); !approxEqual(got, 4, 1e-12) {
+			t.Errorf("KochanekBartels(t=0, TCB %v) = %v; want p1 = 4", tcb, got)
+		}
+		if got := KochanekBartels(1.0, 4, 2, 7, 1, tcb[0], tcb[1], tcb[2]); !approxEqual(got, 2, 1e-12) {
+			t.Errorf("KochanekBartels(t=1, TCB %v) = %v; want p2 = 2", tcb, got)
+		}
+	}
+}
+
+func TestTileBlend(t *testing.T) {
+	cases := []struct {
+		val, want float64
+	}{
+		{0, 0},
+		{3, 0},
+		{7.5, 0},
+		{8, 0.104},
+		{8.75, 0.5},
+		{9, 0.648},
+		{10, 0},
+		{13, 0},
+		{18.75, 0.5},
+		{-1, 0.648},
+	}
+	for _, tc := range cases {
+		if got := TileBlend(tc.val, 10.0); !approxEqual(got, tc.want, 1e-12) {
+			t.Errorf("TileBlend(%v, 10) = %v; want %v", tc.val, got, tc.want)
+		}
+	}
+	prev := 0.0
+	for val := 7.5; val < 10; val += 0.05 {
+		got := TileBlend(val, 10.0)
+		if got < prev {
+			t.Errorf("TileBlend(%v, 10) = %v; want non-decreasing across the ramp", val, got)
+		}
+		prev = got
+	}
+	if got := TileBlend(9.9999, 10.0); !approxEqual(got, 1, 1e-6) {
+		t.Errorf("TileBlend(9.9999, 10) = %v; want close to 1", got)
+	}
+	if got := TileBlend(5.0, 0); got != 0 {
+		t.Errorf("TileBlend(5, 0) = %v; want 0", got)
+	}
+
+	// Blending at the wrapped coordinate is continuous across the seam.
+	sample := func(x float64) float64 { return x * x }
+	blend := func(val float64) float64 {
+		x := WrapPhaseRange(val, 10.0)
+		return Lerp(sample(x), sample(x-10), TileBlend(val, 10.0))
+	}
+	for _, seam := range []float64{10, 20, -10} {
+		if before, after := blend(seam-1e-6), blend(seam); !approxEqual(before, after, 1e-4) {
+			t.Errorf("blend across seam %v: %v then %v; want continuous", seam, before, after)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package genmath
+
+// Interval is a closed range [Min, Max] bundling the free range functions
+// into a value type. Build one with NewInterval so that Min <= Max.
+type Interval[T Real] struct {
+	Min, Max T
+}
+
+// NewInterval returns the interval between a and b, swapping them if they
+// are reversed.
+func NewInterval[T Real](a, b T) Interval[T] {
+	if b < a {
+		a, b = b, a
+	}
+	return Interval[T]{Min: a, Max: b}
+}
+
+// Clamp limits val to [Min, Max].
+func (r Interval[T]) Clamp(val T) T {
+	return Clamp(r.Min, val, r.Max)
+}
+
+// Contains reports whether val lies in the interval, endpoints included.
+func (r Interval[T]) Contains(val T) bool {
+	return val >= r.Min && val <= r.Max
+}
+
+// Lerp interpolates from Min at 0 to Max at 1, extrapolating outside [0, 1].
+func (r Interval[T]) Lerp(amount float64) T {
+	return Lerp(r.Min, r.Max, amount)
+}
+
+// InverseLerp returns where val lies in the interval, 0 at Min and 1 at Max,
+// without clamping. An empty interval returns 0.
+func (r Interval[T]) InverseLerp(val T) float64 {
+	if r.Min == r.Max {
+		return 0
+	}
+	return Range(float64(r.Min), float64(r.Max), float64(val))
+}
+
+// Length returns Max - Min.
+func (r Interval[T]) Length() T {
+	return r.Max - r.Min
+}
+
+// Overlaps reports whether the two intervals share any value, so intervals
+// that only touch at an endpoint overlap.
+func (r Interval[T]) Overlaps(other Interval[T]) bool {
+	return RangesOverlap(r.Min, r.Max, other.Min, other.Max)
+}
@@ -0,0 +1,75 @@
+package genmath
+
+import "testing"
+
+func TestNewInterval(t *testing.T) {
+	if got := NewInterval(uint8(20), 10); got != (Interval[uint8]{Min: 10, Max: 20}) {
+		t.Errorf("NewInterval(20, 10) = %v; want {10 20}", got)
+	}
+	if got := NewInterval(-1.5, 2); got != (Interval[float64]{Min: -1.5, Max: 2}) {
+		t.Errorf("NewInterval(-1.5, 2) = %v; want {-1.5 2}", got)
+	}
+}
+
+func TestIntervalMethods(t *testing.T) {
+	r := NewInterval(20, 10)
+	clampCases := []struct{ val, want int }{{3, 10}, {15, 15}, {25, 20}}
+	for _, tc := range clampCases {
+		if got := r.Clamp(tc.val); got != tc.want {
+			t.Errorf("%v.Clamp(%d) = %d; want %d", r, tc.val, got, tc.want)
+		}
+	}
+	containsCases := []struct {
+		val  int
+		want bool
+	}{{9, false}, {10, true}, {15, true}, {20, true}, {21, false}}
+	for _, tc := range containsCases {
+		if got := r.Contains(tc.val); got != tc.want {
+			t.Errorf("%v.Contains(%d) = %v; want %v", r, tc.val, got, tc.want)
+		}
+	}
+	if got := r.Lerp(0.5); got != 15 {
+		t.Errorf("%v.Lerp(0.5) = %d; want 15", r, got)
+	}
+	if got := r.Lerp(1); got != 20 {
+		t.Errorf("%v.Lerp(1) = %d; want 20", r, got)
+	}
+	inverseCases := []struct {
+		val  int
+		want float64
+	}{{10, 0}, {15, 0.5}, {20, 1}, {5, -0.5}, {25, 1.5}}
+	for _, tc := range inverseCases {
+		if got := r.InverseLerp(tc.val); !approxEqual(got, tc.want, 1e-12) {
+			t.Errorf("%v.InverseLerp(%d) = %v; want %v", r, tc.val, got, tc.want)
+		}
+	}
+	if got := NewInterval(4, 4).InverseLerp(4); got != 0 {
+		t.Errorf("empty interval InverseLerp(4) = %v; want 0", got)
+	}
+	if got := r.Length(); got != 10 {
+		t.Errorf("%v.Length() = %d; want 10", r, got)
+	}
+}
+
+func TestIntervalOverlaps(t *testing.T) {
+	r := NewInterval(10.0, 20)
+	cases := []struct {
+		other Interval[float64]
+		want  bool
+	}{
+		{NewInterval(20.0, 30), true},
+		{NewInterval(0.0, 10), true},
+		{NewInterval(12.0, 15), true},
+		{NewInterval(0.0, 30), true},
+		{NewInterval(30.0, 21), false},
+		{NewInterval(0.0, 9.9), false},
+	}
+	for _, tc := range cases {
+		if got := r.Overlaps(tc.other); got != tc.want {
+			t.Errorf("%v.Overlaps(%v) = %v; want %v", r, tc.other, got, tc.want)
+		}
+		if got := tc.other.Overlaps(r); got != tc.want {
+			t.Errorf("%v.Overlaps(%v) = %v; want %v", tc.other, r, got, tc.want)
+		}
+	}
+}
@@ -47,6 +47,9 @@ const (
 	SMALL_F32   = math.SmallestNonzeroFloat32
 	MAX_F64     = math.MaxFloat64
 	SMALL_F64   = math.SmallestNonzeroFloat64
+
+	EXP_LIMIT_F32 = 88  // Largest whole x where Exp(x) is finite in float32
+	EXP_LIMIT_F64 = 709 // Largest whole x where Exp(x) is finite in float64
 )
 
 type Integer interface {
@@ -101,6 +104,9 @@ type Bool interface {
 	~bool
 }
 
+// Abs returns the absolute value of val. For signed integers the type minimum
+// has no positive counterpart, so Abs(MIN_INT) overflows and returns MIN_INT;
+// use AbsChecked when that input is possible.
 func Abs[T Real](val T) T {
 	if val < 0 {
 		return -val
@@ -108,6 +114,43 @@ func Abs[T Real](val T) T {
 	return val
 }
 
+// CompareMagnitude returns -1, 0 or 1 as |a| is less than, equal to or
+// greater than |b|. Signed values are compared on the negative side, so the
+// signed minimum is handled without overflow.
+func CompareMagnitude[T Real](a, b T) int {
+	if isSigned[T]() {
+		if a > 0 {
+			a = -a
+		}
+		if b > 0 {
+			b = -b
+		}
+		a, b = b, a
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// AbsChecked returns the absolute value of val and false if val is the
+// signed integer minimum (which cannot be negated). Floats are always ok.
+func AbsChecked[T SignedReal](val T) (T, bool) {
+	if val < 0 {
+		neg := -val
+		if neg < 0 {
+			return val, false
+		}
+		return neg, true
+	}
+	return val, true
+}
+
+// Min returns the lesser of a and b. Any comparison with NaN is false, so if
+// either input is NaN the result is b; use MinNaN to propagate NaN instead.
 func Min[T Real](a, b T) T {
 	if a < b {
 		return a
@@ -115,6 +158,8 @@ func Min[T Real](a, b T) T {
 	return b
 }
 
+// Max returns the greater of a and b. Any comparison with NaN is false, so if
+// either input is NaN the result is b; use MaxNaN to propagate NaN instead.
 func Max[T Real](a, b T) T {
 	if a > b {
 		return a
@@ -122,6 +167,30 @@ func Max[T Real](a, b T) T {
 	return b
 }
 
+// MinNaN returns the lesser of a and b, or NaN if either input is NaN,
+// regardless of argument order.
+func MinNaN[T Real](a, b T) T {
+	if a != a {
+		return a
+	}
+	if b != b {
+		return b
+	}
+	return Min(a, b)
+}
+
+// MaxNaN returns the greater of a and b, or NaN if either input is NaN,
+// regardless of argument order.
+func MaxNaN[T Real](a, b T) T {
+	if a != a {
+		return a
+	}
+	if b != b {
+		return b
+	}
+	return Max(a, b)
+}
+
 func Clamp[T Real](min, val, max T) T {
 	if val < min {
 		return min
@@ -132,6 +201,81 @@ func Clamp[T Real](min, val, max T) T {
 	return val
 }
 
+// ClampOrDefault is Clamp except a NaN val returns def instead of passing
+// through unchanged.
+func ClampOrDefault[T Float](min, val, max, def T) T {
+	if val != val {
+		return def
+	}
+	return Clamp(min, val, max)
+}
+
+// ClampMagnitude clamps val to [-Abs(maxMagnitude), Abs(maxMagnitude)].
+func ClampMagnitude[T Real](val, maxMagnitude T) T {
+	mag := Abs(maxMagnitude)
+	if mag < 0 {
+		return val
+	}
+	if val > mag {
+		return mag
+	}
+	if val < 0 && val < -mag {
+		return -mag
+	}
+	return val
+}
+
+func floatNaNMask(bits uint64) uint64 {
+	return uint64(int64(0x7FF0000000000000-(bits&0x7FFFFFFFFFFFFFFF)) >> 63)
+}
+
+// MinF returns the lesser of a and b without data-dependent branches.
+// Unlike Min, if either input is NaN the result is NaN.
+func MinF[T Float](a, b T) T {
+	bitsA, bitsB := FtoU64(float64(a)), FtoU64(float64(b))
+	lessMask := uint64(int64(FtoU64(float64(a)-float64(b))) >> 63)
+	nanMask := floatNaNMask(bitsA) | floatNaNMask(bitsB)
+	bitsMin := bitsA&lessMask | bitsB&^lessMask
+	return T(UtoF64(bitsMin&^nanMask | FtoU64(QNaN64())&nanMask))
+}
+
+// MaxF returns the greater of a and b without data-dependent branches.
+// Unlike Max, if either input is NaN the result is NaN.
+func MaxF[T Float](a, b T) T {
+	bitsA, bitsB := FtoU64(float64(a)), FtoU64(float64(b))
+	lessMask := uint64(int64(FtoU64(float64(a)-float64(b))) >> 63)
+	nanMask := floatNaNMask(bitsA) | floatNaNMask(bitsB)
+	bitsMax := bitsB&lessMask | bitsA&^lessMask
+	return T(UtoF64(bitsMax&^nanMask | FtoU64(QNaN64())&nanMask))
+}
+
+// ClampF clamps val to [min, max] using MinF and MaxF, so a NaN in any
+// argument produces NaN.
+func ClampF[T Float](min, val, max T) T {
+	return MinF(MaxF(val, min), max)
+}
+
+// SmoothClamp is a differentiable approximation of Clamp. It applies a
+// log-sum-exp soft maximum against min and then a soft minimum against max,
+//
+//	softMax(a, b) = max(a, b) + smoothness*log(1 + exp(-|a-b|/smoothness))
+//
+// so it is monotonic, stays within [min, max], tracks val closely away from
+// the bounds, and approaches the hard Clamp as smoothness goes to 0. A
+// smoothness of 0 or less is a hard Clamp.
+func SmoothClamp[T Float](val, min, max, smoothness T) T {
+	if smoothness <= 0 {
+		return Clamp(min, val, max)
+	}
+	fK := float64(smoothness)
+	softMax := func(a, b float64) float64 {
+		return math.Max(a, b) + fK*math.Log1p(math.Exp(-math.Abs(a-b)/fK))
+	}
+	fMin, fMax := float64(min), float64(max)
+	fSoft := -softMax(-softMax(float64(val), fMin), -fMax)
+	return T(Clamp(fMin, fSoft, fMax))
+}
+
 func IMod[T Real](val, div T) T {
 	negV, negD := val < 0, div < 0
 	if negV {
@@ -172,6 +316,25 @@ func Pow[T Real](val, exp T) T {
 	return T(fPow)
 }
 
+// PowSafe is Pow that returns false instead of NaN when val is negative and
+// exp is fractional.
+func PowSafe[T Real](val, exp T) (T, bool) {
+	fVal, fExp := float64(val), float64(exp)
+	if fVal < 0 && math.Trunc(fExp) != fExp {
+		return 0, false
+	}
+	fPow := math.Pow(fVal, fExp)
+	return T(fPow), true
+}
+
+// SignedPow raises the magnitude of val to exp and restores its sign, giving
+// a curve that is odd-symmetric about zero for any exponent.
+func SignedPow[T Real](val, exp T) T {
+	fVal, fExp := float64(val), float64(exp)
+	fPow := math.Pow(math.Abs(fVal), fExp)
+	return T(math.Copysign(fPow, fVal))
+}
+
 func Square[T Real](val T) T {
 	return val * val
 }
@@ -180,6 +343,12 @@ func Cube[T Real](val T) T {
 	return val * val * val
 }
 
+// DiffOfSquares returns a*a - b*b computed as (a-b)*(a+b), which avoids the
+// cancellation error of subtracting two nearly equal squares.
+func DiffOfSquares[T Real](a, b T) T {
+	return (a - b) * (a + b)
+}
+
 func Root[T Real](val, root T) T {
 	return Pow(val, 1/root)
 }
@@ -190,6 +359,58 @@ func Log[T Real](base, val T) T {
 	return T(fLog)
 }
 
+func Exp[T Real](val T) T {
+	fVal := float64(val)
+	fExp := math.Exp(fVal)
+	return T(fExp)
+}
+
+// ExpClamped clamps x to [-maxAbs, maxAbs] before exponentiating so the result
+// stays finite. EXP_LIMIT_F32 and EXP_LIMIT_F64 are the widest safe limits.
+func ExpClamped[T Float](x, maxAbs T) T {
+	maxAbs = Abs(maxAbs)
+	return Exp(Clamp(-maxAbs, x, maxAbs))
+}
+
+// LogSafe is Log that returns false instead of NaN or Inf when val <= 0,
+// base <= 0, or base == 1.
+func LogSafe[T Real](base, val T) (T, bool) {
+	fVal, fBase := float64(val), float64(base)
+	if fVal <= 0 || fBase <= 0 || fBase == 1 {
+		return 0, false
+	}
+	fLog := math.Log(fVal) / math.Log(fBase)
+	return T(fLog), true
+}
+
+// SolveQuadratic returns the real roots of a*x*x + b*x + c = 0 in ascending
+// order, with a double root returned once. The second root comes from the
+// product of roots (c/a) to avoid cancellation when b*b is much larger than
+// 4*a*c. If a is 0 the single linear root is returned. ok is false when there
+// are no real roots, including when a and b are both 0.
+func SolveQuadratic[T Float](a, b, c T) (roots []T, ok bool) {
+	fA, fB, fC := float64(a), float64(b), float64(c)
+	if fA == 0 {
+		if fB == 0 {
+			return nil, false
+		}
+		return []T{T(-fC / fB)}, true
+	}
+	disc := fB*fB - 4*fA*fC
+	if disc < 0 {
+		return []T{}, false
+	}
+	if disc == 0 {
+		return []T{T(-fB / (2 * fA))}, true
+	}
+	q := -0.5 * (fB + math.Copysign(math.Sqrt(disc), fB))
+	x1, x2 := q/fA, fC/q
+	if x1 > x2 {
+		x1, x2 = x2, x1
+	}
+	return []T{T(x1), T(x2)}, true
+}
+
 func Cos[T Real](radians T) T {
 	fVal := float64(radians)
 	fCos := math.Cos(fVal)
@@ -300,6 +521,60 @@ func Lerp[T Real](start T, end T, amount float64) T {
 	return start + T(lerp)
 }
 
+// LerpUnclamped is Lerp, named to make explicit that amounts outside [0, 1]
+// extrapolate past start and end rather than being clamped.
+func LerpUnclamped[T Real](start T, end T, amount float64) T {
+	return Lerp(start, end, amount)
+}
+
+// Relax moves current toward target by factor, where factors above 1
+// over-relax (overshoot to speed convergence) and below 1 under-relax. A
+// factor of exactly 1 returns target.
+func Relax[T Float](current, target, factor T) T {
+	if factor == 1 {
+		return target
+	}
+	return current + (target-current)*factor
+}
+
+// Mix is an alias of Lerp using the name common in shader languages.
+func Mix[T Real](a, b T, t float64) T {
+	return Lerp(a, b, t)
+}
+
+// Blend returns the weighted average of values, normalizing weights so they
+// need not sum to 1. Empty input returns zero, and weights summing to zero
+// return NaN (or zero for integer types). It panics if the slices differ in
+// length.
+func Blend[T Real](values []T, weights []float64) T {
+	if len(values) != len(weights) {
+		panic("genmath: slice length mismatch")
+	}
+	if len(values) == 0 {
+		return 0
+	}
+	sum, total := 0.0, 0.0
+	for i, val := range values {
+		sum += float64(val) * weights[i]
+		total += weights[i]
+	}
+	if total == 0 {
+		if isFloat[T]() {
+			return T(QNaN64())
+		}
+		return 0
+	}
+	return T(sum / total)
+}
+
+// Bilerp interpolates across a unit square whose corners are c00 (0,0),
+// c10 (1,0), c01 (0,1) and c11 (1,1).
+func Bilerp[T Real](c00, c10, c01, c11 T, tx, ty float64) T {
+	fBottom := Lerp(float64(c00), float64(c10), tx)
+	fTop := Lerp(float64(c01), float64(c11), tx)
+	return T(Lerp(fBottom, fTop, ty))
+}
+
 func Range[T Real](start T, end T, val T) float64 {
 	diff := end - start
 	lerp := val - start
@@ -307,10 +582,48 @@ func Range[T Real](start T, end T, val T) float64 {
 	return percent
 }
 
+// InverseLerpClamped is Range clamped to [0, 1]. Reversed bounds are
+// swapped first, so the result always measures from the lower bound, and an
+// empty interval returns 0.
+func InverseLerpClamped[T Real](start T, end T, val T) float64 {
+	if start == end {
+		return 0
+	}
+	if start > end {
+		start, end = end, start
+	}
+	fStart, fEnd, fVal := float64(start), float64(end), float64(val)
+	return Clamp(0, (fVal-fStart)/(fEnd-fStart), 1)
+}
+
 func RoundClamp[T Real](min, val, max T) T {
 	return Clamp(min, Round(val), max)
 }
 
+// Deadzone returns 0 while the magnitude of value is below threshold and
+// otherwise rescales magnitudes in [threshold, 1] to [0, 1], keeping the sign,
+// so the output is continuous at the threshold. A threshold of 0 or less
+// means no deadzone, and 1 or more always returns 0. A NaN value returns 0 so
+// that a corrupt sample never reads as full deflection.
+func Deadzone[T Float](value, threshold T) T {
+	threshold = Max(threshold, 0)
+	mag := Abs(value)
+	if !(mag >= threshold) || threshold >= 1 {
+		return 0
+	}
+	scaled := Min((mag-threshold)/(1-threshold), 1)
+	return Sign(value) * scaled
+}
+
+// AccelCurve shapes a stick or mouse input in [-1, 1] into an output in
+// [-maxOutput, maxOutput]: it removes deadzone (in [0, 1)) with Deadzone,
+// raises the rescaled magnitude to exponent with SignedPow and scales by
+// maxOutput. An exponent of 1 is linear, above 1 gives finer control near the
+// center and below 1 (but above 0) a quicker response.
+func AccelCurve[T Float](input, deadzone, exponent, maxOutput T) T {
+	return SignedPow(Deadzone(input, deadzone), exponent) * maxOutput
+}
+
 func FIntFrac[T Float](value T) (T, T) {
 	i, f := math.Modf(float64(value))
 	return T(i), T(f)
@@ -358,6 +671,73 @@ func QuickIntegral[T Real](from T, to T, resolution T, formula func(x T) T) T {
 	return sum
 }
 
+func Trapezoidal[T Real](from T, to T, n int, formula func(x T) T) T {
+	if n < 1 {
+		n = 1
+	}
+	fFrom, fTo := float64(from), float64(to)
+	step := (fTo - fFrom) / float64(n)
+	sum := (float64(formula(from)) + float64(formula(to))) / 2
+	for i := 1; i < n; i += 1 {
+		sum += float64(formula(T(fFrom + float64(i)*step)))
+	}
+	return T(sum * step)
+}
+
+// CumulativeIntegral returns n+1 samples of the trapezoidal integral of
+// formula from `from` to each of n+1 evenly spaced points ending at `to`.
+func CumulativeIntegral[T Real](from T, to T, n int, formula func(x T) T) []T {
+	if n < 1 {
+		n = 1
+	}
+	fFrom, fTo := float64(from), float64(to)
+	step := (fTo - fFrom) / float64(n)
+	samples := make([]T, n+1)
+	sum, yLo := 0.0, float64(formula(from))
+	for i := 1; i <= n; i += 1 {
+		x := T(fFrom + float64(i)*step)
+		if i == n {
+			x = to
+		}
+		yHi := float64(formula(x))
+		sum += (yLo + yHi) / 2 * step
+		samples[i] = T(sum)
+		yLo = yHi
+	}
+	return samples
+}
+
+// Romberg integrates formula over [from, to] by Richardson extrapolation of
+// successively halved trapezoidal estimates, stopping after maxSteps rows or
+// once two consecutive diagonal estimates differ by no more than tol.
+func Romberg[T Real](from T, to T, maxSteps int, tol T, formula func(x T) T) T {
+	if maxSteps < 1 {
+		maxSteps = 1
+	}
+	fFrom, fTo, fTol := float64(from), float64(to), float64(tol)
+	step := fTo - fFrom
+	prev := []float64{step * (float64(formula(from)) + float64(formula(to))) / 2}
+	for i := 1; i < maxSteps; i += 1 {
+		step /= 2
+		sum := 0.0
+		for k := 1; k < 1<<i; k += 2 {
+			sum += float64(formula(T(fFrom + float64(k)*step)))
+		}
+		curr := make([]float64, i+1)
+		curr[0] = prev[0]/2 + step*sum
+		factor := 1.0
+		for j := 1; j <= i; j += 1 {
+			factor *= 4
+			curr[j] = curr[j-1] + (curr[j-1]-prev[j-1])/(factor-1)
+		}
+		if math.Abs(curr[i]-prev[i-1]) <= fTol {
+			return T(curr[i])
+		}
+		prev = curr
+	}
+	return T(prev[len(prev)-1])
+}
+
 func RangesOverlap[T Real](startA, endA, startB, endB T) bool {
 	return startA <= endB && startB <= endA
 }
@@ -395,6 +775,43 @@ func NInf64() float64 {
 	return math.Float64frombits(0xFFF0000000000000)
 }
 
+type FloatClass uint8
+
+const (
+	ClassZero FloatClass = iota
+	ClassSubnormal
+	ClassNormal
+	ClassInf
+	ClassNaN
+)
+
+func IsFinite[T Float](val T) bool {
+	class := Classify(val)
+	return class != ClassInf && class != ClassNaN
+}
+
+func Classify[T Float](val T) FloatClass {
+	var exp, expMax, frac uint64
+	if unsafe.Sizeof(val) == 4 {
+		bits := FtoU32(float32(val))
+		exp, expMax, frac = uint64(bits>>23)&0xFF, 0xFF, uint64(bits)&0x7FFFFF
+	} else {
+		bits := FtoU64(float64(val))
+		exp, expMax, frac = (bits>>52)&0x7FF, 0x7FF, bits&0xFFFFFFFFFFFFF
+	}
+	switch {
+	case exp == expMax && frac != 0:
+		return ClassNaN
+	case exp == expMax:
+		return ClassInf
+	case exp == 0 && frac != 0:
+		return ClassSubnormal
+	case exp == 0:
+		return ClassZero
+	}
+	return ClassNormal
+}
+
 func UtoF32(u uint32) float32 {
 	return *(*float32)(unsafe.Pointer(&u))
 }
@@ -0,0 +1,518 @@
+package genmath
+
+import (
+	"math"
+	"testing"
+)
+
+func approxEqual(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
+
+func TestAbsChecked(t *testing.T) {
+	if v, ok := AbsChecked(int8(MIN_I8)); ok || v != MIN_I8 {
+		t.Errorf("AbsChecked(MIN_I8) = %v, %v; want %v, false", v, ok, MIN_I8)
+	}
+	if v, ok := AbsChecked(int32(MIN_I32)); ok || v != MIN_I32 {
+		t.Errorf("AbsChecked(MIN_I32) = %v, %v; want %v, false", v, ok, MIN_I32)
+	}
+	if v, ok := AbsChecked(int64(MIN_I64)); ok || v != MIN_I64 {
+		t.Errorf("AbsChecked(MIN_I64) = %v, %v; want %v, false", v, ok, MIN_I64)
+	}
+	if v, ok := AbsChecked(int8(MIN_I8 + 1)); !ok || v != MAX_I8 {
+		t.Errorf("AbsChecked(MIN_I8+1) = %v, %v; want %v, true", v, ok, MAX_I8)
+	}
+	if v, ok := AbsChecked(-2.5); !ok || v != 2.5 {
+		t.Errorf("AbsChecked(-2.5) = %v, %v; want 2.5, true", v, ok)
+	}
+}
+
+func TestPowSafe(t *testing.T) {
+	if v, ok := PowSafe(-8.0, 1.0/3); ok {
+		t.Errorf("PowSafe(-8, 1/3) = %v, true; want false", v)
+	}
+	if v, ok := PowSafe(-2.0, 3); !ok || v != -8 {
+		t.Errorf("PowSafe(-2, 3) = %v, %v; want -8, true", v, ok)
+	}
+	if v, ok := PowSafe(4.0, 0.5); !ok || v != 2 {
+		t.Errorf("PowSafe(4, 0.5) = %v, %v; want 2, true", v, ok)
+	}
+}
+
+func TestLogSafe(t *testing.T) {
+	domainErrors := []struct{ base, val float64 }{
+		{10, 0},
+		{10, -1},
+		{0, 10},
+		{-2, 10},
+		{1, 10},
+	}
+	for _, tc := range domainErrors {
+		if v, ok := LogSafe(tc.base, tc.val); ok {
+			t.Errorf("LogSafe(%v, %v) = %v, true; want false", tc.base, tc.val, v)
+		}
+	}
+	if v, ok := LogSafe(2.0, 8); !ok || !approxEqual(v, 3, 1e-12) {
+		t.Errorf("LogSafe(2, 8) = %v, %v; want 3, true", v, ok)
+	}
+}
+
+func TestTrapezoidal(t *testing.T) {
+	square := func(x float64) float64 { return x * x }
+	if got := Trapezoidal(0, 1, 1000, square); !approxEqual(got, 1.0/3, 1e-6) {
+		t.Errorf("Trapezoidal(x^2, 0, 1) = %v; want 1/3", got)
+	}
+	if got := Trapezoidal(0, PI, 1000, math.Sin); !approxEqual(got, 2, 1e-5) {
+		t.Errorf("Trapezoidal(sin, 0, PI) = %v; want 2", got)
+	}
+}
+
+func TestRomberg(t *testing.T) {
+	if got := Romberg(0, PI, 20, 1e-12, math.Sin); !approxEqual(got, 2, 1e-10) {
+		t.Errorf("Romberg(sin, 0, PI) = %v; want 2", got)
+	}
+	if got := Romberg(0, 1, 20, 1e-12, math.Exp); !approxEqual(got, E-1, 1e-10) {
+		t.Errorf("Romberg(exp, 0, 1) = %v; want e-1", got)
+	}
+}
+
+func TestRombergEvaluationsVersusQuickIntegral(t *testing.T) {
+	rombergCalls, quickCalls := 0, 0
+	romberg := Romberg(0, PI, 20, 1e-9, func(x float64) float64 {
+		rombergCalls += 1
+		return math.Sin(x)
+	})
+	quick := QuickIntegral(0, PI, 1e-4, func(x float64) float64 {
+		quickCalls += 1
+		return math.Sin(x)
+	})
+	if !approxEqual(romberg, 2, 1e-8) || !approxEqual(quick, 2, 1e-6) {
+		t.Fatalf("Romberg = %v, QuickIntegral = %v; want 2", romberg, quick)
+	}
+	if rombergCalls*100 > quickCalls {
+		t.Errorf("Romberg used %d evaluations and QuickIntegral %d; want at least 100x fewer", rombergCalls, quickCalls)
+	}
+}
+
+func TestBilerp(t *testing.T) {
+	cases := []struct{ tx, ty, want float64 }{
+		{0, 0, 1},
+		{1, 0, 2},
+		{0, 1, 3},
+		{1, 1, 6},
+		{0.5, 0.5, 3},
+		{0.5, 0, 1.5},
+	}
+	for _, tc := range cases {
+		if got := Bilerp(1.0, 2, 3, 6, tc.tx, tc.ty); !approxEqual(got, tc.want, 1e-12) {
+			t.Errorf("Bilerp(1, 2, 3, 6, %v, %v) = %v; want %v", tc.tx, tc.ty, got, tc.want)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		name string
+		got  FloatClass
+		want FloatClass
+	}{
+		{"QNaN32", Classify(QNaN32()), ClassNaN},
+		{"QNaN64", Classify(QNaN64()), ClassNaN},
+		{"PInf32", Classify(PInf32()), ClassInf},
+		{"NInf64", Classify(NInf64()), ClassInf},
+		{"SMALL_F32 as float32", Classify(float32(SMALL_F32)), ClassSubnormal},
+		{"SMALL_F32 as float64", Classify(float64(SMALL_F32)), ClassNormal},
+		{"SMALL_F64", Classify(SMALL_F64), ClassSubnormal},
+		{"zero", Classify(float32(0)), ClassZero},
+		{"negative zero", Classify(math.Copysign(0, -1)), ClassZero},
+		{"one", Classify(1.0), ClassNormal},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("Classify(%s) = %d; want %d", tc.name, tc.got, tc.want)
+		}
+	}
+}
+
+func TestIsFinite(t *testing.T) {
+	if IsFinite(QNaN64()) || IsFinite(PInf32()) || IsFinite(NInf64()) {
+		t.Errorf("IsFinite reported NaN or Inf as finite")
+	}
+	if !IsFinite(float32(SMALL_F32)) || !IsFinite(MAX_F64) || !IsFinite(0.0) {
+		t.Errorf("IsFinite reported a finite value as non-finite")
+	}
+}
+
+func TestMinMaxFNaN(t *testing.T) {
+	nan := QNaN64()
+	pairs := [][2]float64{{nan, 1}, {1, nan}, {nan, nan}}
+	for _, p := range pairs {
+		if got := MinF(p[0], p[1]); !math.IsNaN(got) {
+			t.Errorf("MinF(%v, %v) = %v; want NaN", p[0], p[1], got)
+		}
+		if got := MaxF(p[0], p[1]); !math.IsNaN(got) {
+			t.Errorf("MaxF(%v, %v) = %v; want NaN", p[0], p[1], got)
+		}
+	}
+	triples := [][3]float64{{nan, 5, 10}, {0, nan, 10}, {0, 5, nan}}
+	for _, c := range triples {
+		if got := ClampF(c[0], c[1], c[2]); !math.IsNaN(got) {
+			t.Errorf("ClampF(%v, %v, %v) = %v; want NaN", c[0], c[1], c[2], got)
+		}
+	}
+	if got := MinF(float32(2), -3); got != -3 {
+		t.Errorf("MinF(2, -3) = %v; want -3", got)
+	}
+	if got := MaxF(-1.5, -2.5); got != -1.5 {
+		t.Errorf("MaxF(-1.5, -2.5) = %v; want -1.5", got)
+	}
+	if got := ClampF(0.0, 12, 10); got != 10 {
+		t.Errorf("ClampF(0, 12, 10) = %v; want 10", got)
+	}
+}
+
+var benchSink float64
+
+func benchInputs() []float64 {
+	vals := make([]float64, 1024)
+	for i := range vals {
+		vals[i] = math.Sin(float64(i) * 12.9898)
+	}
+	return vals
+}
+
+func BenchmarkMinF(b *testing.B) {
+	vals := benchInputs()
+	for i := 0; i < b.N; i += 1 {
+		benchSink = MinF(vals[i&1023], vals[(i+1)&1023])
+	}
+}
+
+func BenchmarkMin(b *testing.B) {
+	vals := benchInputs()
+	for i := 0; i < b.N; i += 1 {
+		benchSink = Min(vals[i&1023], vals[(i+1)&1023])
+	}
+}
+
+func BenchmarkMathMin(b *testing.B) {
+	vals := benchInputs()
+	for i := 0; i < b.N; i += 1 {
+		benchSink = math.Min(vals[i&1023], vals[(i+1)&1023])
+	}
+}
+
+func TestMinMaxNaNOrder(t *testing.T) {
+	nan := QNaN64()
+	if got := Min(nan, 1.0); got != 1 {
+		t.Errorf("Min(NaN, 1) = %v; want 1", got)
+	}
+	if got := Min(1.0, nan); !math.IsNaN(got) {
+		t.Errorf("Min(1, NaN) = %v; want NaN", got)
+	}
+	if got := Max(nan, 1.0); got != 1 {
+		t.Errorf("Max(NaN, 1) = %v; want 1", got)
+	}
+	if got := Max(1.0, nan); !math.IsNaN(got) {
+		t.Errorf("Max(1, NaN) = %v; want NaN", got)
+	}
+	for _, p := range [][2]float64{{nan, 1}, {1, nan}} {
+		if got := MinNaN(p[0], p[1]); !math.IsNaN(got) {
+			t.Errorf("MinNaN(%v, %v) = %v; want NaN", p[0], p[1], got)
+		}
+		if got := MaxNaN(p[0], p[1]); !math.IsNaN(got) {
+			t.Errorf("MaxNaN(%v, %v) = %v; want NaN", p[0], p[1], got)
+		}
+	}
+	if got := MinNaN(3, -2); got != -2 {
+		t.Errorf("MinNaN(3, -2) = %v; want -2", got)
+	}
+	if got := MaxNaN(3, -2); got != 3 {
+		t.Errorf("MaxNaN(3, -2) = %v; want 3", got)
+	}
+}
+
+func TestMixMatchesLerp(t *testing.T) {
+	for _, amount := range []float64{0, 0.25, 0.5, 1, 1.5} {
+		if got, want := Mix(2.0, 10, amount), Lerp(2.0, 10, amount); got != want {
+			t.Errorf("Mix(2, 10, %v) = %v; want Lerp result %v", amount, got, want)
+		}
+	}
+}
+
+func TestBlend(t *testing.T) {
+	if got := Blend([]float64{0, 10, 20}, []float64{1, 2, 1}); !approxEqual(got, 10, 1e-12) {
+		t.Errorf("Blend([0 10 20], [1 2 1]) = %v; want 10", got)
+	}
+	if got := Blend([]float64{0, 10, 20}, []float64{0.2, 0.3, 0.5}); !approxEqual(got, 13, 1e-12) {
+		t.Errorf("Blend([0 10 20], [0.2 0.3 0.5]) = %v; want 13", got)
+	}
+	if got := Blend([]float64{1, 2}, []float64{0, 0}); !math.IsNaN(got) {
+		t.Errorf("Blend with zero weights = %v; want NaN", got)
+	}
+}
+
+func TestBlendLengthMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Blend with a short weights slice did not panic")
+		}
+	}()
+	Blend([]float64{1, 2, 3}, []float64{1, 1})
+}
+
+func TestInverseLerpClamped(t *testing.T) {
+	cases := []struct{ start, end, val, want float64 }{
+		{0, 10, -5, 0},
+		{0, 10, 15, 1},
+		{0, 10, 2, 0.2},
+		{10, 0, 2, 0.2},
+		{5, 5, 7, 0},
+	}
+	for _, tc := range cases {
+		if got := InverseLerpClamped(tc.start, tc.end, tc.val); !approxEqual(got, tc.want, 1e-12) {
+			t.Errorf("InverseLerpClamped(%v, %v, %v) = %v; want %v", tc.start, tc.end, tc.val, got, tc.want)
+		}
+	}
+}
+
+func TestDeadzone(t *testing.T) {
+	cases := []struct{ value, threshold, want float64 }{
+		{0.1, 0.2, 0},
+		{-0.19, 0.2, 0},
+		{0.2, 0.2, 0},
+		{0.21, 0.2, 0.0125},
+		{-0.6, 0.2, -0.5},
+		{1, 0.2, 1},
+		{-3, 0.2, -1},
+		{0, -1, 0},
+		{0.5, -1, 0.5},
+		{0.5, 1, 0},
+		{math.NaN(), 0.2, 0},
+		{math.NaN(), 0, 0},
+	}
+	for _, tc := range cases {
+		if got := Deadzone(tc.value, tc.threshold); !approxEqual(got, tc.want, 1e-12) {
+			t.Errorf("Deadzone(%v, %v) = %v; want %v", tc.value, tc.threshold, got, tc.want)
+		}
+	}
+}
+
+func TestDiffOfSquaresError(t *testing.T) {
+	a, b := float32(10000.001), float32(10000)
+	exact := (float64(a) - float64(b)) * (float64(a) + float64(b))
+	factored := float64(DiffOfSquares(a, b))
+	naive := float64(a*a - b*b)
+	errFactored, errNaive := math.Abs(factored-exact), math.Abs(naive-exact)
+	if errFactored >= errNaive {
+		t.Errorf("DiffOfSquares error %v is not below the naive error %v", errFactored, errNaive)
+	}
+	if !approxEqual(factored, exact, 1e-6*math.Abs(exact)) {
+		t.Errorf("DiffOfSquares(%v, %v) = %v; want %v", a, b, factored, exact)
+	}
+}
+
+func TestSignedPow(t *testing.T) {
+	cases := []struct{ val, exp, want float64 }{
+		{4, 0.5, 2},
+		{-4, 0.5, -2},
+		{-8, 1.0 / 3, -2},
+		{-2, 2, -4},
+		{3, 2, 9},
+		{0, 0.5, 0},
+	}
+	for _, tc := range cases {
+		if got := SignedPow(tc.val, tc.exp); !approxEqual(got, tc.want, 1e-12) {
+			t.Errorf("SignedPow(%v, %v) = %v; want %v", tc.val, tc.exp, got, tc.want)
+		}
+	}
+	for _, val := range []float64{0.3, 1.7, 25} {
+		if pos, neg := SignedPow(val, 1.5), SignedPow(-val, 1.5); neg != -pos {
+			t.Errorf("SignedPow(-%v, 1.5) = %v; want %v", val, neg, -pos)
+		}
+	}
+}
+
+func TestExpClamped(t *testing.T) {
+	if got := ExpClamped(1000.0, EXP_LIMIT_F64); math.IsInf(got, 0) {
+		t.Errorf("ExpClamped(1000, EXP_LIMIT_F64) = %v; want finite", got)
+	}
+	if got := ExpClamped(float32(1000), EXP_LIMIT_F32); math.IsInf(float64(got), 0) {
+		t.Errorf("ExpClamped(float32(1000), EXP_LIMIT_F32) = %v; want finite", got)
+	}
+	if got := ExpClamped(-1000.0, EXP_LIMIT_F64); got != math.Exp(-EXP_LIMIT_F64) {
+		t.Errorf("ExpClamped(-1000, EXP_LIMIT_F64) = %v; want Exp(-EXP_LIMIT_F64)", got)
+	}
+	for _, x := range []float64{-5, 0, 1.5, 20} {
+		if got := ExpClamped(x, 50); got != math.Exp(x) {
+			t.Errorf("ExpClamped(%v, 50) = %v; want %v", x, got, math.Exp(x))
+		}
+	}
+}
+
+func TestClampMagnitude(t *testing.T) {
+	cases := []struct{ val, maxMagnitude, want float64 }{
+		{12, 10, 10},
+		{-12, 10, -10},
+		{5, 10, 5},
+		{-5, 10, -5},
+		{12, -10, 10},
+	}
+	for _, tc := range cases {
+		if got := ClampMagnitude(tc.val, tc.maxMagnitude); got != tc.want {
+			t.Errorf("ClampMagnitude(%v, %v) = %v; want %v", tc.val, tc.maxMagnitude, got, tc.want)
+		}
+	}
+	if got := ClampMagnitude(uint8(200), 100); got != 100 {
+		t.Errorf("ClampMagnitude(uint8(200), 100) = %v; want 100", got)
+	}
+}
+
+func TestCumulativeIntegral(t *testing.T) {
+	samples := CumulativeIntegral(0, PI, 100, math.Sin)
+	if len(samples) != 101 {
+		t.Fatalf("len(CumulativeIntegral) = %d; want 101", len(samples))
+	}
+	if samples[0] != 0 {
+		t.Errorf("CumulativeIntegral first sample = %v; want 0", samples[0])
+	}
+	if want := Trapezoidal(0, PI, 100, math.Sin); !approxEqual(samples[100], want, 1e-12) {
+		t.Errorf("CumulativeIntegral last sample = %v; want Trapezoidal %v", samples[100], want)
+	}
+	if !approxEqual(samples[50], 1, 1e-3) {
+		t.Errorf("CumulativeIntegral midpoint = %v; want about 1", samples[50])
+	}
+}
+
+func TestSolveQuadratic(t *testing.T) {
+	cases := []struct {
+		name    string
+		a, b, c float64
+		want    []float64
+		ok      bool
+	}{
+		{"separated", 1, -3, 2, []float64{1, 2}, true},
+		{"cancellation", 1, -1e8, 1, []float64{1e-8, 1e8}, true},
+		{"double", 1, -4, 4, []float64{2}, true},
+		{"linear", 0, 2, -4, []float64{2}, true},
+		{"no real roots", 1, 0, 1, nil, false},
+		{"degenerate", 0, 0, 1, nil, false},
+	}
+	for _, tc := range cases {
+		roots, ok := SolveQuadratic(tc.a, tc.b, tc.c)
+		if ok != tc.ok || len(roots) != len(tc.want) {
+			t.Errorf("%s: SolveQuadratic = %v, %v; want %v, %v", tc.name, roots, ok, tc.want, tc.ok)
+			continue
+		}
+		for i := range roots {
+			if !approxEqual(roots[i], tc.want[i], 1e-12*math.Abs(tc.want[i])) {
+				t.Errorf("%s: root %d = %v; want %v", tc.name, i, roots[i], tc.want[i])
+			}
+		}
+	}
+}
+
+func TestSmoothClamp(t *testing.T) {
+	for _, val := range []float64{-5, 0.5, 3, 9.5, 20} {
+		if got, want := SmoothClamp(val, 0, 10, 1e-3), Clamp(0, val, 10); !approxEqual(got, want, 1e-2) {
+			t.Errorf("SmoothClamp(%v, 0, 10, 1e-3) = %v; want about %v", val, got, want)
+		}
+	}
+	prev := SmoothClamp(-20.0, 0, 10, 1)
+	for val := -20.0; val <= 30; val += 0.25 {
+		got := SmoothClamp(val, 0, 10, 1)
+		if got < prev || got < 0 || got > 10 {
+			t.Errorf("SmoothClamp(%v, 0, 10, 1) = %v; want monotonic within [0, 10]", val, got)
+		}
+		prev = got
+	}
+	if got := SmoothClamp(12.0, 0, 10, 0); got != 10 {
+		t.Errorf("SmoothClamp(12, 0, 10, 0) = %v; want hard clamp 10", got)
+	}
+}
+
+func TestClampOrDefault(t *testing.T) {
+	cases := []struct{ val, want float64 }{
+		{QNaN64(), -1},
+		{PInf64(), 10},
+		{NInf64(), 0},
+		{5, 5},
+		{12, 10},
+	}
+	for _, tc := range cases {
+		if got := ClampOrDefault(0, tc.val, 10, -1); got != tc.want {
+			t.Errorf("ClampOrDefault(0, %v, 10, -1) = %v; want %v", tc.val, got, tc.want)
+		}
+	}
+}
+
+func TestCompareMagnitude(t *testing.T) {
+	cases := []struct {
+		a, b int64
+		want int
+	}{
+		{MIN_I64, MAX_I64, 1},
+		{MAX_I64, MIN_I64, -1},
+		{MIN_I64, MIN_I64, 0},
+		{-5, 3, 1},
+		{3, -5, -1},
+		{-4, 4, 0},
+	}
+	for _, tc := range cases {
+		if got := CompareMagnitude(tc.a, tc.b); got != tc.want {
+			t.Errorf("CompareMagnitude(%d, %d) = %d; want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+	if got := CompareMagnitude(uint8(200), 100); got != 1 {
+		t.Errorf("CompareMagnitude(uint8(200), 100) = %d; want 1", got)
+	}
+	if got := CompareMagnitude(-2.5, 2.5); got != 0 {
+		t.Errorf("CompareMagnitude(-2.5, 2.5) = %d; want 0", got)
+	}
+}
+
+func TestRelax(t *testing.T) {
+	cases := []struct{ current, target, factor, want float64 }{
+		{0, 1, 1, 1},
+		{0, 1, 0.5, 0.5},
+		{0, 1, 1.5, 1.5},
+		{2, 1, 1.5, 0.5},
+		{4, 4, 1.8, 4},
+	}
+	for _, tc := range cases {
+		if got := Relax(tc.current, tc.target, tc.factor); !approxEqual(got, tc.want, 1e-12) {
+			t.Errorf("Relax(%v, %v, %v) = %v; want %v", tc.current, tc.target, tc.factor, got, tc.want)
+		}
+	}
+}
+
+func TestAccelCurve(t *testing.T) {
+	cases := []struct {
+		input, exponent, want float64
+	}{
+		{0, 2, 0},
+		{0.05, 2, 0},
+		{-0.1, 2, 0},
+		{0.55, 1, 5},
+		{0.55, 2, 2.5},
+		{-0.55, 2, -2.5},
+		{0.55, 0.5, 10 * 0.7071067811865476},
+		{1, 2, 10},
+		{-1, 2, -10},
+		{1.5, 2, 10},
+	}
+	for _, tc := range cases {
+		if got := AccelCurve(tc.input, 0.1, tc.exponent, 10.0); !approxEqual(got, tc.want, 1e-12) {
+			t.Errorf("AccelCurve(%v, 0.1, %v, 10) = %v; want %v", tc.input, tc.exponent, got, tc.want)
+		}
+	}
+	for _, input := range []float64{0.2, 0.4, 0.6, 0.8} {
+		linear := AccelCurve(input, 0.1, 1, 10.0)
+		if curved := AccelCurve(input, 0.1, 2, 10.0); curved >= linear {
+			t.Errorf("AccelCurve(%v, exponent 2) = %v; want below linear %v", input, curved, linear)
+		}
+		if curved := AccelCurve(input, 0.1, 0.5, 10.0); curved <= linear {
+			t.Errorf("AccelCurve(%v, exponent 0.5) = %v; want above linear %v", input, curved, linear)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package genmath
+
+import "math"
+
+// PeakMeter follows the peak magnitude of a signal, jumping up to each new
+// peak instantly and decaying by multiplying with release on every push.
+// A release of 0 tracks the raw magnitude and values near 1 decay slowly.
+type PeakMeter[T Float] struct {
+	release T
+	level   T
+}
+
+func NewPeakMeter[T Float](release T) *PeakMeter[T] {
+	return &PeakMeter[T]{release: Clamp(0, release, 1)}
+}
+
+func (p *PeakMeter[T]) Push(sample T) {
+	p.level = Max(Abs(sample), p.level*p.release)
+}
+
+func (p *PeakMeter[T]) Level() T {
+	return p.level
+}
+
+// Lanczos returns the Lanczos kernel of order a at x, sinc(x) * sinc(x/a)
+// for |x| < a and 0 elsewhere, using the normalized sinc.
+func Lanczos[T Float](x T, a int) T {
+	fX, fA := float64(x), float64(a)
+	if fX == 0 {
+		return 1
+	}
+	if math.Abs(fX) >= fA {
+		return 0
+	}
+	piX := PI * fX
+	return T(fA * math.Sin(piX) * math.Sin(piX/fA) / (piX * piX))
+}
+
+// SoftClip passes x through unchanged while its magnitude is at most
+// threshold and above that compresses it toward +-1 along
+//
+//	threshold + (1-threshold) * tanh((|x|-threshold) / (1-threshold))
+//
+// which matches both the value and slope of x at the threshold and never
+// exceeds 1. threshold is clamped to [0, 1], and 1 gives a hard clip.
+func SoftClip[T Float](x, threshold T) T {
+	fX, fT := float64(x), Clamp(0, float64(threshold), 1)
+	mag := math.Abs(fX)
+	if mag <= fT {
+		return x
+	}
+	if fT == 1 {
+		return T(math.Copysign(1, fX))
+	}
+	knee := 1 - fT
+	return T(math.Copysign(fT+knee*math.Tanh((mag-fT)/knee), fX))
+}
+
+// ByteRangeToUnit maps val in [0, maxValue] to [0, 1], clamping out-of-range
+// values. A maxValue of 0 or less returns 0.
+func ByteRangeToUnit[T Integer](val T, maxValue T) float64 {
+	if maxValue <= 0 {
+		return 0
+	}
+	return Clamp(0, float64(val)/float64(maxValue), 1)
+}
+
+func MidiToNormalized(value uint8) float64 {
+	return ByteRangeToUnit(value, 127)
+}
+
+// NormalizedToMidi maps value in [0, 1] to the nearest MIDI value in
+// [0, 127], clamping out-of-range input.
+func NormalizedToMidi(value float64) uint8 {
+	return QuantizeUnit(value, uint8(128))
+}
+
+// LinearToDB converts an amplitude gain to decibels (20*log10). A gain of 0 or
+// less returns negative infinity.
+func LinearToDB[T Float](gain T) T {
+	if gain <= 0 {
+		return T(math.Inf(-1))
+	}
+	return T(20 * math.Log10(float64(gain)))
+}
+
+func DBToLinear[T Float](db T) T {
+	return T(math.Pow(10, float64(db)/20))
+}
+
+// PowerToDB converts a power ratio to decibels (10*log10). A ratio of 0 or
+// less returns negative infinity.
+func PowerToDB[T Float](power T) T {
+	if power <= 0 {
+		return T(math.Inf(-1))
+	}
+	return T(10 * math.Log10(float64(power)))
+}
+
+func DBToPower[T Float](db T) T {
+	return T(math.Pow(10, float64(db)/10))
+}
+
+const A4_FREQUENCY = 440 // Standard concert pitch of A4 (MIDI note 69) in hertz
+
+// NoteToFrequency returns the equal-temperament frequency in hertz of a
+// (possibly fractional) MIDI note, tuned so note 69 is a4 hertz. An a4 of 0
+// or less uses A4_FREQUENCY.
+func NoteToFrequency(midiNote float64, a4 float64) float64 {
+	if a4 <= 0 {
+		a4 = A4_FREQUENCY
+	}
+	return a4 * math.Exp2((midiNote-69)/12)
+}
+
+// FrequencyToNote is the inverse of NoteToFrequency, returning a fractional
+// MIDI note.
+func FrequencyToNote(freq, a4 float64) float64 {
+	if a4 <= 0 {
+		a4 = A4_FREQUENCY
+	}
+	return 69 + 12*math.Log2(freq/a4)
+}
+
+// SmoothExponential returns the exponential moving average of vals, seeded
+// with the first value. It panics unless alpha is in (0, 1].
+func SmoothExponential[T Float](vals []T, alpha T) []T {
+	if !(alpha > 0 && alpha <= 1) {
+		panic("genmath: smoothing alpha must be in (0, 1]")
+	}
+	smoothed := make([]T, len(vals))
+	for i, val := range vals {
+		if i == 0 {
+			smoothed[i] = val
+			continue
+		}
+		smoothed[i] = smoothed[i-1] + alpha*(val-smoothed[i-1])
+	}
+	return smoothed
+}
+
+// SmoothExponentialBidirectional runs SmoothExponential forward and then
+// backward over the result, cancelling the lag of a single pass. It panics
+// unless alpha is in (0, 1].
+func SmoothExponentialBidirectional[T Float](vals []T, alpha T) []T {
+	smoothed := SmoothExponential(vals, alpha)
+	for i := len(smoothed) - 2; i >= 0; i -= 1 {
+		smoothed[i] = smoothed[i+1] + alpha*(smoothed[i]-smoothed[i+1])
+	}
+	return smoothed
+}
+
+// Hysteresis is a two-threshold switch (a Schmitt trigger): State turns on
+// once an input reaches High and only turns off again once an input falls to
+// Low, so noise between the thresholds never toggles it.
+type Hysteresis[T Real] struct {
+	Low   T
+	High  T
+	State bool
+}
+
+func (h *Hysteresis[T]) Update(val T) bool {
+	if h.State {
+		h.State = val > h.Low
+	} else {
+		h.State = val >= h.High
+	}
+	return h.State
+}
+
+// PID is a proportional-integral-derivative controller whose output is
+// clamped to [Min, Max]. The accumulated integral term is clamped to the same
+// limits so it cannot wind up while the output is saturated. If Min >= Max the
+// output is unlimited.
+type PID[T Float] struct {
+	Kp, Ki, Kd T
+	Min, Max   T
+
+	integral T
+	prevErr  T
+	started  bool
+}
+
+// Update advances the controller by dt and returns the new output. The
+// derivative term is skipped on the first update after creation or Reset.
+func (p *PID[T]) Update(setpoint, measured, dt T) T {
+	err := setpoint - measured
+	limited := p.Min < p.Max
+	p.integral += p.Ki * err * dt
+	if limited {
+		p.integral = Clamp(p.Min, p.integral, p.Max)
+	}
+	deriv := T(0)
+	if p.started && dt > 0 {
+		deriv = (err - p.prevErr) / dt
+	}
+	p.prevErr, p.started = err, true
+	out := p.Kp*err + p.integral + p.Kd*deriv
+	if limited {
+		out = Clamp(p.Min, out, p.Max)
+	}
+	return out
+}
+
+func (p *PID[T]) Reset() {
+	p.integral, p.prevErr, p.started = 0, 0, false
+}
+
+// Sawtooth returns a wave with period TAU that rises linearly from -1 at
+// phase 0 and jumps back to -1 at the end of each period, staying in [-1, 1).
+func Sawtooth[T Float](phase T) T {
+	return 2*WrapPhase(phase)/TAU - 1
+}
+
+// Triangle returns a triangle wave with period TAU aligned with Sin: 0 at
+// phase 0, 1 at a quarter period, 0 at half and -1 at three quarters.
+func Triangle[T Float](phase T) T {
+	pos := WrapPhase(phase) / TAU
+	switch {
+	case pos < 0.25:
+		return 4 * pos
+	case pos < 0.75:
+		return 2 - 4*pos
+	default:
+		return 4*pos - 4
+	}
+}
+
+// Pulse returns 1 for the first dutyCycle fraction of each TAU period and -1
+// for the rest. dutyCycle is clamped to [0, 1], and 0.5 gives a square wave.
+func Pulse[T Float](phase, dutyCycle T) T {
+	if WrapPhase(phase)/TAU < Clamp(0, dutyCycle, 1) {
+		return 1
+	}
+	return -1
+}
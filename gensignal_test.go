@@ -0,0 +1,272 @@
+package genmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPeakMeter(t *testing.T) {
+	meter := NewPeakMeter(0.5)
+	meter.Push(0.1)
+	meter.Push(-0.8)
+	if got := meter.Level(); got != 0.8 {
+		t.Fatalf("Level after spike = %v; want 0.8", got)
+	}
+	want := []float64{0.4, 0.2, 0.1}
+	for i, w := range want {
+		meter.Push(0)
+		if got := meter.Level(); !approxEqual(got, w, 1e-12) {
+			t.Errorf("Level after %d silent pushes = %v; want %v", i+1, got, w)
+		}
+	}
+	meter.Push(0.3)
+	if got := meter.Level(); got != 0.3 {
+		t.Errorf("Level after louder sample = %v; want 0.3", got)
+	}
+}
+
+func TestLanczos(t *testing.T) {
+	if got := Lanczos(0.0, 3); got != 1 {
+		t.Errorf("Lanczos(0, 3) = %v; want 1", got)
+	}
+	for _, x := range []float64{1, 2, -1, -2} {
+		if got := Lanczos(x, 3); !approxEqual(got, 0, 1e-15) {
+			t.Errorf("Lanczos(%v, 3) = %v; want 0", x, got)
+		}
+	}
+	for _, x := range []float64{3, 3.5, -4} {
+		if got := Lanczos(x, 3); got != 0 {
+			t.Errorf("Lanczos(%v, 3) = %v; want 0 outside the window", x, got)
+		}
+	}
+	if got := Lanczos(0.5, 3); !(got > 0 && got < 1) {
+		t.Errorf("Lanczos(0.5, 3) = %v; want in (0, 1)", got)
+	}
+}
+
+func TestSoftClip(t *testing.T) {
+	for _, x := range []float64{0, 0.3, -0.5, 0.6} {
+		if got := SoftClip(x, 0.6); got != x {
+			t.Errorf("SoftClip(%v, 0.6) = %v; want unchanged", x, got)
+		}
+	}
+	prev := SoftClip(0.6, 0.6)
+	for x := 0.61; x < 50; x *= 1.1 {
+		got := SoftClip(x, 0.6)
+		if got > 1 || got < prev {
+			t.Errorf("SoftClip(%v, 0.6) = %v; want monotonic and at most 1", x, got)
+		}
+		if neg := SoftClip(-x, 0.6); neg != -got {
+			t.Errorf("SoftClip(-%v, 0.6) = %v; want %v", x, neg, -got)
+		}
+		prev = got
+	}
+	if got := SoftClip(5.0, 1); got != 1 {
+		t.Errorf("SoftClip(5, 1) = %v; want hard clip to 1", got)
+	}
+}
+
+func TestMidiConversions(t *testing.T) {
+	cases := []struct {
+		midi uint8
+		norm float64
+	}{
+		{0, 0},
+		{64, 64.0 / 127},
+		{127, 1},
+	}
+	for _, tc := range cases {
+		if got := MidiToNormalized(tc.midi); !approxEqual(got, tc.norm, 1e-12) {
+			t.Errorf("MidiToNormalized(%d) = %v; want %v", tc.midi, got, tc.norm)
+		}
+		if got := NormalizedToMidi(tc.norm); got != tc.midi {
+			t.Errorf("NormalizedToMidi(%v) = %d; want %d", tc.norm, got, tc.midi)
+		}
+	}
+	if got := MidiToNormalized(200); got != 1 {
+		t.Errorf("MidiToNormalized(200) = %v; want 1 (clamped)", got)
+	}
+	if got := NormalizedToMidi(-0.5); got != 0 {
+		t.Errorf("NormalizedToMidi(-0.5) = %d; want 0", got)
+	}
+	if got := NormalizedToMidi(1.5); got != 127 {
+		t.Errorf("NormalizedToMidi(1.5) = %d; want 127", got)
+	}
+}
+
+func TestDecibels(t *testing.T) {
+	if got := LinearToDB(1.0); got != 0 {
+		t.Errorf("LinearToDB(1) = %v; want 0", got)
+	}
+	if got := LinearToDB(2.0); !approxEqual(got, 6.0206, 1e-4) {
+		t.Errorf("LinearToDB(2) = %v; want 6.02", got)
+	}
+	if got := PowerToDB(2.0); !approxEqual(got, 3.0103, 1e-4) {
+		t.Errorf("PowerToDB(2) = %v; want 3.01", got)
+	}
+	if got := LinearToDB(0.0); !math.IsInf(got, -1) {
+		t.Errorf("LinearToDB(0) = %v; want -Inf", got)
+	}
+	for _, gain := range []float64{0.01, 0.5, 1, 3, 100} {
+		if got := DBToLinear(LinearToDB(gain)); !approxEqual(got, gain, 1e-12*gain) {
+			t.Errorf("DBToLinear(LinearToDB(%v)) = %v", gain, got)
+		}
+		if got := DBToPower(PowerToDB(gain)); !approxEqual(got, gain, 1e-12*gain) {
+			t.Errorf("DBToPower(PowerToDB(%v)) = %v", gain, got)
+		}
+	}
+}
+
+func TestNoteFrequency(t *testing.T) {
+	if got := NoteToFrequency(69, 0); got != A4_FREQUENCY {
+		t.Errorf("NoteToFrequency(69) = %v; want %v", got, A4_FREQUENCY)
+	}
+	if got := NoteToFrequency(81, 440); !approxEqual(got, 880, 1e-9) {
+		t.Errorf("NoteToFrequency(81) = %v; want 880", got)
+	}
+	if got := NoteToFrequency(60, 440); !approxEqual(got, 261.6256, 1e-4) {
+		t.Errorf("NoteToFrequency(60) = %v; want 261.6256", got)
+	}
+	if got := NoteToFrequency(69, 432); got != 432 {
+		t.Errorf("NoteToFrequency(69, 432) = %v; want 432", got)
+	}
+	for _, note := range []float64{0, 21, 60.5, 69, 108, 127} {
+		if got := FrequencyToNote(NoteToFrequency(note, 440), 440); !approxEqual(got, note, 1e-9) {
+			t.Errorf("FrequencyToNote(NoteToFrequency(%v)) = %v", note, got)
+		}
+	}
+}
+
+func TestSmoothExponential(t *testing.T) {
+	for i, val := range SmoothExponential([]float64{3, 3, 3, 3}, 0.3) {
+		if val != 3 {
+			t.Errorf("SmoothExponential(constant)[%d] = %v; want 3", i, val)
+		}
+	}
+	for i, val := range SmoothExponentialBidirectional([]float64{3, 3, 3, 3}, 0.3) {
+		if val != 3 {
+			t.Errorf("SmoothExponentialBidirectional(constant)[%d] = %v; want 3", i, val)
+		}
+	}
+	// A ramp of slope 1 lags by (1-alpha)/alpha after one pass; the
+	// backward pass cancels that lag away from the ends.
+	ramp := make([]float64, 101)
+	for i := range ramp {
+		ramp[i] = float64(i)
+	}
+	mid := 50
+	if got := SmoothExponential(ramp, 0.5)[mid]; !approxEqual(got, 49, 1e-9) {
+		t.Errorf("SmoothExponential(ramp)[%d] = %v; want 49 (lag of 1)", mid, got)
+	}
+	if got := SmoothExponentialBidirectional(ramp, 0.5)[mid]; !approxEqual(got, 50, 1e-9) {
+		t.Errorf("SmoothExponentialBidirectional(ramp)[%d] = %v; want 50 (no lag)", mid, got)
+	}
+}
+
+func TestSmoothExponentialAlpha(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SmoothExponential with alpha 0 did not panic")
+		}
+	}()
+	SmoothExponential([]float64{1, 2}, 0)
+}
+
+func TestHysteresisNoisySignal(t *testing.T) {
+	h := Hysteresis[float64]{Low: 0.4, High: 0.6}
+	signal := []float64{0.1, 0.5, 0.45, 0.55, 0.62, 0.5, 0.45, 0.58, 0.41, 0.39, 0.5, 0.59, 0.6}
+	want := []bool{false, false, false, false, true, true, true, true, true, false, false, false, true}
+	toggles := 0
+	prev := h.State
+	for i, val := range signal {
+		got := h.Update(val)
+		if got != want[i] {
+			t.Errorf("Update(%v) at step %d = %v; want %v", val, i, got, want[i])
+		}
+		if got != prev {
+			toggles += 1
+		}
+		prev = got
+	}
+	if toggles != 3 {
+		t.Errorf("noisy signal toggled %d times; want 3", toggles)
+	}
+}
+
+func TestPIDConverges(t *testing.T) {
+	pid := PID[float64]{Kp: 2, Ki: 1, Kd: 0.05, Min: -10, Max: 10}
+	measured, dt := 0.0, 0.01
+	for i := 0; i < 2000; i += 1 {
+		out := pid.Update(5, measured, dt)
+		measured += (out - measured) * dt // first-order plant
+	}
+	if !approxEqual(measured, 5, 1e-2) {
+		t.Errorf("PID settled at %v; want 5", measured)
+	}
+}
+
+func TestPIDAntiWindup(t *testing.T) {
+	pid := PID[float64]{Ki: 1, Min: -1, Max: 1}
+	for i := 0; i < 1000; i += 1 {
+		if out := pid.Update(10, 0, 1); out > 1 {
+			t.Fatalf("PID output %v exceeds Max", out)
+		}
+	}
+	// With the integral clamped at Max, a negative error unwinds it in a few
+	// steps instead of the ~10000 an unclamped integral would need.
+	var out float64
+	for i := 0; i < 3; i += 1 {
+		out = pid.Update(0, 0.5, 1)
+	}
+	if out >= 0 {
+		t.Errorf("PID output after windup and 3 negative-error steps = %v; want negative", out)
+	}
+	pid.Reset()
+	if out := pid.Update(0, 0, 1); out != 0 {
+		t.Errorf("PID output after Reset with zero error = %v; want 0", out)
+	}
+}
+
+func TestSawtoothTriangle(t *testing.T) {
+	cases := []struct{ fraction, saw, tri float64 }{
+		{0, -1, 0},
+		{0.25, -0.5, 1},
+		{0.5, 0, 0},
+		{0.75, 0.5, -1},
+		{1, -1, 0},
+		{-0.25, 0.5, -1},
+	}
+	for _, tc := range cases {
+		phase := tc.fraction * TAU
+		if got := Sawtooth(phase); !approxEqual(got, tc.saw, 1e-12) {
+			t.Errorf("Sawtooth(%v*TAU) = %v; want %v", tc.fraction, got, tc.saw)
+		}
+		if got := Triangle(phase); !approxEqual(got, tc.tri, 1e-12) {
+			t.Errorf("Triangle(%v*TAU) = %v; want %v", tc.fraction, got, tc.tri)
+		}
+	}
+	if got := Sawtooth(0.999999 * TAU); !(got < 1 && got > 0.99) {
+		t.Errorf("Sawtooth just before the reset = %v; want just below 1", got)
+	}
+}
+
+func TestPulse(t *testing.T) {
+	cases := []struct{ fraction, duty, want float64 }{
+		{0, 0.5, 1},
+		{0.49, 0.5, 1},
+		{0.5, 0.5, -1},
+		{0.2, 0.25, 1},
+		{0.3, 0.25, -1},
+		{0.8, 0.9, 1},
+		{0, 0, -1},
+		{0.99, 1, 1},
+		{0.99, 2, 1},
+		{1.1, 0.25, 1},
+	}
+	for _, tc := range cases {
+		got := Pulse(tc.fraction*TAU, tc.duty)
+		if got != tc.want {
+			t.Errorf("Pulse(%v*TAU, %v) = %v; want %v", tc.fraction, tc.duty, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,432 @@
+package genmath
+
+import (
+	"math"
+	"sort"
+)
+
+// Gradient returns the derivative at each sample of vals using central
+// differences in the interior and one-sided differences at the endpoints.
+func Gradient[T Real](vals []T, spacing T) []float64 {
+	grad := make([]float64, len(vals))
+	if len(vals) < 2 {
+		return grad
+	}
+	fSpacing := float64(spacing)
+	last := len(vals) - 1
+	grad[0] = (float64(vals[1]) - float64(vals[0])) / fSpacing
+	for i := 1; i < last; i += 1 {
+		grad[i] = (float64(vals[i+1]) - float64(vals[i-1])) / (2 * fSpacing)
+	}
+	grad[last] = (float64(vals[last]) - float64(vals[last-1])) / fSpacing
+	return grad
+}
+
+func Sum[T Real](vals []T) T {
+	sum := T(0)
+	for _, val := range vals {
+		sum += val
+	}
+	return sum
+}
+
+// SumKahan sums vals with Kahan-Babuska (Neumaier) compensation, which keeps
+// the error bounded regardless of slice length. Prefer it over Sum for large
+// slices or values of very different magnitudes.
+func SumKahan[T Float](vals []T) T {
+	sum, comp := T(0), T(0)
+	for _, val := range vals {
+		next := sum + val
+		if Abs(sum) >= Abs(val) {
+			comp += (sum - next) + val
+		} else {
+			comp += (val - next) + sum
+		}
+		sum = next
+	}
+	return sum + comp
+}
+
+// Histogram counts vals into bins equal-width bins spanning [min, max],
+// ignoring values outside that range. Returns nil if bins <= 0.
+func Histogram[T Real](vals []T, min, max T, bins int) []int {
+	return histogram(vals, min, max, bins, false)
+}
+
+// HistogramClamped is Histogram except values outside [min, max] are counted
+// in the first or last bin.
+func HistogramClamped[T Real](vals []T, min, max T, bins int) []int {
+	return histogram(vals, min, max, bins, true)
+}
+
+func histogram[T Real](vals []T, min, max T, bins int, clamp bool) []int {
+	if bins <= 0 {
+		return nil
+	}
+	counts := make([]int, bins)
+	fMin, fWidth := float64(min), float64(max)-float64(min)
+	for _, val := range vals {
+		if val != val {
+			continue
+		}
+		if val < min || val > max {
+			if !clamp {
+				continue
+			}
+			val = Clamp(min, val, max)
+		}
+		bin := 0
+		if fWidth > 0 {
+			bin = int((float64(val) - fMin) / fWidth * float64(bins))
+		}
+		if bin >= bins {
+			bin = bins - 1
+		}
+		counts[bin] += 1
+	}
+	return counts
+}
+
+// NthElement returns the k-th smallest value in vals (k starting at 0) in
+// average O(n) time, without modifying vals. It panics if k is out of range.
+func NthElement[T Real](vals []T, k int) T {
+	if k < 0 || k >= len(vals) {
+		panic("genmath: NthElement index out of range")
+	}
+	work := append([]T(nil), vals...)
+	return quickSelect(work, k)
+}
+
+func quickSelect[T Real](vals []T, k int) T {
+	lo, hi := 0, len(vals)-1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		pivot := Max(Min(vals[lo], vals[mid]), Min(Max(vals[lo], vals[mid]), vals[hi]))
+		i, j := lo, hi
+		for i <= j {
+			for vals[i] < pivot {
+				i += 1
+			}
+			for vals[j] > pivot {
+				j -= 1
+			}
+			if i <= j {
+				vals[i], vals[j] = vals[j], vals[i]
+				i, j = i+1, j-1
+			}
+		}
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return vals[k]
+		}
+	}
+	return vals[k]
+}
+
+// Nearest returns the candidate closest to target and its index, preferring
+// the earliest on ties. Empty candidates return (0, -1).
+func Nearest[T Real](target T, candidates []T) (value T, index int) {
+	index = -1
+	bestDist := 0.0
+	for i, candidate := range candidates {
+		dist := math.Abs(float64(candidate) - float64(target))
+		if index == -1 || dist < bestDist {
+			value, index, bestDist = candidate, i, dist
+		}
+	}
+	return value, index
+}
+
+// NearestSorted is Nearest for candidates sorted in ascending order, using a
+// binary search.
+func NearestSorted[T Real](target T, candidates []T) (value T, index int) {
+	if len(candidates) == 0 {
+		return 0, -1
+	}
+	index = sort.Search(len(candidates), func(i int) bool { return candidates[i] >= target })
+	if index == len(candidates) {
+		index -= 1
+	} else if index > 0 {
+		distLo := float64(target) - float64(candidates[index-1])
+		distHi := float64(candidates[index]) - float64(target)
+		if distLo <= distHi {
+			index -= 1
+		}
+	}
+	return candidates[index], index
+}
+
+// SampleIndex linearly interpolates table at the fractional index pos, which
+// is clamped to [0, len(table)-1]. An empty table returns 0 and a NaN pos
+// returns table[0].
+func SampleIndex[T Real](table []T, pos float64) T {
+	if len(table) == 0 {
+		return 0
+	}
+	if pos != pos {
+		return table[0]
+	}
+	last := len(table) - 1
+	pos = Clamp(0, pos, float64(last))
+	idx := int(pos)
+	if idx >= last {
+		return table[last]
+	}
+	frac := pos - float64(idx)
+	return T(Lerp(float64(table[idx]), float64(table[idx+1]), frac))
+}
+
+// Softmax returns exp(logit) / sum(exp(logits)) for each logit, subtracting
+// the largest logit first so no intermediate overflows.
+func Softmax[T Float](logits []T) []float64 {
+	probs := make([]float64, len(logits))
+	if len(logits) == 0 {
+		return probs
+	}
+	fMax := float64(logits[0])
+	for _, logit := range logits[1:] {
+		fMax = Max(fMax, float64(logit))
+	}
+	sum := 0.0
+	for i, logit := range logits {
+		probs[i] = math.Exp(float64(logit) - fMax)
+		sum += probs[i]
+	}
+	for i := range probs {
+		probs[i] /= sum
+	}
+	return probs
+}
+
+// LogSumExp returns log(sum(exp(vals))) computed relative to the largest value
+// so it stays finite where the direct form would overflow. An empty slice
+// returns negative infinity.
+func LogSumExp[T Float](vals []T) T {
+	if len(vals) == 0 {
+		return T(math.Inf(-1))
+	}
+	fMax := float64(vals[0])
+	for _, val := range vals[1:] {
+		fMax = Max(fMax, float64(val))
+	}
+	if math.IsInf(fMax, 0) {
+		return T(fMax)
+	}
+	sum := 0.0
+	for _, val := range vals {
+		sum += math.Exp(float64(val) - fMax)
+	}
+	return T(fMax + math.Log(sum))
+}
+
+// Dot returns the sum of the element-wise products of a and b. It panics if
+// the slices differ in length.
+func Dot[T Real](a, b []T) T {
+	if len(a) != len(b) {
+		panic("genmath: slice length mismatch")
+	}
+	dot := T(0)
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return dot
+}
+
+// AddScaled computes dst[i] += src[i] * scale in place. It panics if the
+// slices differ in length.
+func AddScaled[T Real](dst []T, src []T, scale T) {
+	AddScaledTo(dst, dst, src, scale)
+}
+
+// AddScaledTo computes dst[i] = base[i] + src[i]*scale. It panics if the
+// slices differ in length.
+func AddScaledTo[T Real](dst []T, base []T, src []T, scale T) {
+	if len(dst) != len(base) || len(dst) != len(src) {
+		panic("genmath: slice length mismatch")
+	}
+	for i := range dst {
+		dst[i] = base[i] + src[i]*scale
+	}
+}
+
+// IsMonotonicIncreasing reports whether each value is at least the one before
+// it. Empty and single-value slices are monotonic, and any NaN fails.
+func IsMonotonicIncreasing[T Real](vals []T) bool {
+	for i := 1; i < len(vals); i += 1 {
+		if !(vals[i] >= vals[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsMonotonicDecreasing reports whether each value is at most the one before
+// it. Empty and single-value slices are monotonic, and any NaN fails.
+func IsMonotonicDecreasing[T Real](vals []T) bool {
+	for i := 1; i < len(vals); i += 1 {
+		if !(vals[i] <= vals[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsStrictlyIncreasing is IsMonotonicIncreasing except equal neighbours fail.
+func IsStrictlyIncreasing[T Real](vals []T) bool {
+	for i := 1; i < len(vals); i += 1 {
+		if !(vals[i] > vals[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsStrictlyDecreasing is IsMonotonicDecreasing except equal neighbours fail.
+func IsStrictlyDecreasing[T Real](vals []T) bool {
+	for i := 1; i < len(vals); i += 1 {
+		if !(vals[i] < vals[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ArgMin returns the element of vals with the smallest key and its index,
+// preferring the earliest on ties. Empty input returns the zero value and -1.
+func ArgMin[T any](vals []T, key func(T) float64) (T, int) {
+	var best T
+	bestIdx, bestKey := -1, 0.0
+	for i, val := range vals {
+		k := key(val)
+		if bestIdx == -1 || k < bestKey {
+			best, bestIdx, bestKey = val, i, k
+		}
+	}
+	return best, bestIdx
+}
+
+// ArgMax returns the element of vals with the largest key and its index,
+// preferring the earliest on ties. Empty input returns the zero value and -1.
+func ArgMax[T any](vals []T, key func(T) float64) (T, int) {
+	var best T
+	bestIdx, bestKey := -1, 0.0
+	for i, val := range vals {
+		k := key(val)
+		if bestIdx == -1 || k > bestKey {
+			best, bestIdx, bestKey = val, i, k
+		}
+	}
+	return best, bestIdx
+}
+
+// IntegrateTrapezoidXY returns the trapezoidal area under the samples
+// (xs[i], ys[i]), where xs is sorted ascending but need not be evenly spaced.
+// It panics if the slices differ in length; fewer than 2 points give 0.
+func IntegrateTrapezoidXY[T Real](xs, ys []T) float64 {
+	if len(xs) != len(ys) {
+		panic("genmath: slice length mismatch")
+	}
+	area := 0.0
+	for i := 1; i < len(xs); i += 1 {
+		width := float64(xs[i]) - float64(xs[i-1])
+		area += width * (float64(ys[i]) + float64(ys[i-1])) / 2
+	}
+	return area
+}
+
+// DedupApprox returns a sorted copy of vals in which each run of values whose
+// consecutive gaps are at most epsilon is replaced by the mean of the run.
+// vals itself is not modified, and NaNs are dropped.
+func DedupApprox[T Float](vals []T, epsilon T) []T {
+	sorted := make([]T, 0, len(vals))
+	for _, val := range vals {
+		if val == val {
+			sorted = append(sorted, val)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	deduped := make([]T, 0, len(sorted))
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && sorted[end]-sorted[end-1] <= epsilon {
+			end += 1
+		}
+		deduped = append(deduped, Sum(sorted[start:end])/T(end-start))
+		start = end
+	}
+	return deduped
+}
+
+// Linspace returns count evenly spaced values from start to end inclusive.
+// A count of 1 returns [start] and a count of 0 or less an empty slice.
+func Linspace[T Real](start, end T, count int) []T {
+	if count <= 0 {
+		return []T{}
+	}
+	vals := make([]T, count)
+	vals[0] = start
+	fStart, fEnd := float64(start), float64(end)
+	for i := 1; i < count; i += 1 {
+		vals[i] = T(Lerp(fStart, fEnd, float64(i)/float64(count-1)))
+	}
+	if count > 1 {
+		vals[count-1] = end
+	}
+	return vals
+}
+
+// Logspace returns count values from base^startExp to base^endExp inclusive
+// with evenly spaced exponents.
+func Logspace[T Float](startExp, endExp T, count int, base T) []T {
+	vals := Linspace(startExp, endExp, count)
+	for i, exp := range vals {
+		vals[i] = Pow(base, exp)
+	}
+	return vals
+}
+
+// Arange returns start, start+step, start+2*step, ... up to but excluding
+// stop. A negative step counts down, and a step of 0 or one pointing away from
+// stop returns an empty slice. It panics if the count would be infinite or
+// exceed MAX_INT.
+func Arange[T Real](start, stop, step T) []T {
+	fStart, fStep := float64(start), float64(step)
+	fCount := math.Ceil((float64(stop) - fStart) / fStep)
+	if step == 0 || !(fCount > 0) {
+		return []T{}
+	}
+	if fCount >= float64(MAX_INT) {
+		panic("genmath: Arange count out of range")
+	}
+	vals := make([]T, int(fCount))
+	for i := range vals {
+		vals[i] = T(fStart + float64(i)*fStep)
+	}
+	return vals
+}
+
+// CountOutOfRange returns how many of vals are below min and how many are
+// above max. NaNs are counted in neither.
+func CountOutOfRange[T Real](vals []T, min, max T) (below, above int) {
+	for _, val := range vals {
+		if val < min {
+			below += 1
+		} else if val > max {
+			above += 1
+		}
+	}
+	return below, above
+}
+
+// Bucketize returns the index of the bucket val falls into given bucket edges
+// sorted in ascending order: 0 below edges[0], i for edges[i-1] <= val <
+// edges[i], and len(edges) at or above the last edge. A value exactly on an
+// edge belongs to the bucket above it. Unsorted edges give meaningless
+// results.
+func Bucketize[T Real](val T, edges []T) int {
+	return sort.Search(len(edges), func(i int) bool { return edges[i] > val })
+}
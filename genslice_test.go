@@ -0,0 +1,377 @@
+package genmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGradient(t *testing.T) {
+	linear := []float64{1, 4, 7, 10, 13}
+	for i, g := range Gradient(linear, 1) {
+		if !approxEqual(g, 3, 1e-12) {
+			t.Errorf("Gradient(linear)[%d] = %v; want 3", i, g)
+		}
+	}
+	// x^2 sampled at x = 0, 0.5, ..., 2: central differences are exact in
+	// the interior and the endpoints are off by h.
+	quadratic := []float64{0, 0.25, 1, 2.25, 4}
+	want := []float64{0.5, 1, 2, 3, 3.5}
+	for i, g := range Gradient(quadratic, 0.5) {
+		if !approxEqual(g, want[i], 1e-12) {
+			t.Errorf("Gradient(quadratic)[%d] = %v; want %v", i, g, want[i])
+		}
+	}
+	if got := Gradient([]int{5}, 1); len(got) != 1 || got[0] != 0 {
+		t.Errorf("Gradient(single) = %v; want [0]", got)
+	}
+}
+
+func TestSumKahan(t *testing.T) {
+	// float32 has a spacing of 8 at 1e8, so each 1.0 is lost by a naive sum.
+	ones := make([]float32, 1, 1001)
+	ones[0] = 1e8
+	for i := 0; i < 1000; i += 1 {
+		ones = append(ones, 1)
+	}
+	if got := SumKahan(ones); got != 1e8+1000 {
+		t.Errorf("SumKahan(float32 1e8 + 1000*1.0) = %v; want %v", got, float32(1e8+1000))
+	}
+	if naive := Sum(ones); naive == 1e8+1000 {
+		t.Errorf("Sum(float32 1e8 + 1000*1.0) = %v; expected it to lose the ones", naive)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	uniform := make([]float64, 100)
+	for i := range uniform {
+		uniform[i] = float64(i) / 10
+	}
+	for i, count := range Histogram(uniform, 0, 10, 5) {
+		if count != 20 {
+			t.Errorf("Histogram(uniform)[%d] = %d; want 20", i, count)
+		}
+	}
+	// max falls in the last bin; values outside [min, max] are dropped.
+	edges := []float64{-1, 0, 5, 10, 11}
+	got := Histogram(edges, 0, 10, 2)
+	if got[0] != 1 || got[1] != 2 {
+		t.Errorf("Histogram(edges) = %v; want [1 2]", got)
+	}
+	got = HistogramClamped(edges, 0, 10, 2)
+	if got[0] != 2 || got[1] != 3 {
+		t.Errorf("HistogramClamped(edges) = %v; want [2 3]", got)
+	}
+	if Histogram(edges, 0, 10, 0) != nil {
+		t.Errorf("Histogram with 0 bins is not nil")
+	}
+}
+
+func TestNearest(t *testing.T) {
+	unsorted := []float64{7, -2, 3.5, 10, 3}
+	cases := []struct {
+		target, want float64
+		index        int
+	}{
+		{4, 3.5, 2},
+		{10, 10, 3},
+		{-100, -2, 1},
+		{3.25, 3.5, 2}, // tie between 3.5 and 3 keeps the earlier one
+	}
+	for _, tc := range cases {
+		if got, idx := Nearest(tc.target, unsorted); got != tc.want || idx != tc.index {
+			t.Errorf("Nearest(%v) = %v, %d; want %v, %d", tc.target, got, idx, tc.want, tc.index)
+		}
+	}
+	if _, idx := Nearest(1.0, nil); idx != -1 {
+		t.Errorf("Nearest(empty) index = %d; want -1", idx)
+	}
+}
+
+func TestNearestSorted(t *testing.T) {
+	sorted := []int{-2, 3, 7, 10}
+	cases := []struct{ target, want, index int }{
+		{7, 7, 2},
+		{8, 7, 2},
+		{9, 10, 3},
+		{5, 3, 1}, // tie between 3 and 7 keeps the lower one
+		{-50, -2, 0},
+		{50, 10, 3},
+	}
+	for _, tc := range cases {
+		if got, idx := NearestSorted(tc.target, sorted); got != tc.want || idx != tc.index {
+			t.Errorf("NearestSorted(%d) = %d, %d; want %d, %d", tc.target, got, idx, tc.want, tc.index)
+		}
+	}
+}
+
+func TestSampleIndex(t *testing.T) {
+	table := []float64{0, 10, 30, 60}
+	cases := []struct{ pos, want float64 }{
+		{0, 0},
+		{0.5, 5},
+		{1.25, 15},
+		{2.5, 45},
+		{3, 60},
+		{-2, 0},
+		{10, 60},
+		{QNaN64(), 0},
+	}
+	for _, tc := range cases {
+		if got := SampleIndex(table, tc.pos); !approxEqual(got, tc.want, 1e-12) {
+			t.Errorf("SampleIndex(table, %v) = %v; want %v", tc.pos, got, tc.want)
+		}
+	}
+	if got := SampleIndex([]int{}, 1); got != 0 {
+		t.Errorf("SampleIndex(empty) = %v; want 0", got)
+	}
+}
+
+func TestSoftmax(t *testing.T) {
+	logits := []float64{1, 2, 3, 0.5}
+	probs := Softmax(logits)
+	if sum := Sum(probs); !approxEqual(sum, 1, 1e-12) {
+		t.Errorf("Softmax sums to %v; want 1", sum)
+	}
+	shifted := Softmax([]float64{101, 102, 103, 100.5})
+	for i := range probs {
+		if !approxEqual(probs[i], shifted[i], 1e-12) {
+			t.Errorf("Softmax shifted[%d] = %v; want %v", i, shifted[i], probs[i])
+		}
+	}
+	large := Softmax([]float64{1000, 1000, 0})
+	if !approxEqual(large[0], 0.5, 1e-12) || !approxEqual(large[1], 0.5, 1e-12) || large[2] != 0 {
+		t.Errorf("Softmax(large logits) = %v; want [0.5 0.5 0]", large)
+	}
+}
+
+func TestLogSumExp(t *testing.T) {
+	vals := []float64{0.5, -1, 2, 1.25}
+	naive := 0.0
+	for _, val := range vals {
+		naive += math.Exp(val)
+	}
+	if got := LogSumExp(vals); !approxEqual(got, math.Log(naive), 1e-12) {
+		t.Errorf("LogSumExp(%v) = %v; want %v", vals, got, math.Log(naive))
+	}
+	if got := LogSumExp([]float64{1000, 1000}); !approxEqual(got, 1000+LOG_E_2, 1e-9) {
+		t.Errorf("LogSumExp(1000, 1000) = %v; want 1000+ln(2)", got)
+	}
+	if got := LogSumExp([]float32{-1000, -1000}); math.IsInf(float64(got), 0) {
+		t.Errorf("LogSumExp(float32 -1000, -1000) = %v; want finite", got)
+	}
+	if got := LogSumExp([]float64{}); !math.IsInf(got, -1) {
+		t.Errorf("LogSumExp(empty) = %v; want -Inf", got)
+	}
+}
+
+func TestAddScaled(t *testing.T) {
+	acc := []float64{1, 2, 3}
+	AddScaled(acc, []float64{1, 1, 1}, 2)
+	AddScaled(acc, []float64{0, 1, -1}, 0.5)
+	want := []float64{3, 4.5, 4.5}
+	for i := range want {
+		if acc[i] != want[i] {
+			t.Errorf("AddScaled accumulation = %v; want %v", acc, want)
+			break
+		}
+	}
+	dst := make([]int, 3)
+	base := []int{1, 2, 3}
+	AddScaledTo(dst, base, []int{4, 5, 6}, 10)
+	if dst[0] != 41 || dst[1] != 52 || dst[2] != 63 || base[0] != 1 {
+		t.Errorf("AddScaledTo = %v with base %v; want [41 52 63] and base unchanged", dst, base)
+	}
+}
+
+func TestAddScaledLengthMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("AddScaled with mismatched lengths did not panic")
+		}
+	}()
+	AddScaled([]float64{1, 2}, []float64{1}, 1)
+}
+
+func TestMonotonic(t *testing.T) {
+	cases := []struct {
+		name                 string
+		vals                 []float64
+		inc, dec, sInc, sDec bool
+	}{
+		{"strictly increasing", []float64{1, 2, 5}, true, false, true, false},
+		{"strictly decreasing", []float64{5, 2, 1}, false, true, false, true},
+		{"plateau", []float64{1, 2, 2, 3}, true, false, false, false},
+		{"constant", []float64{4, 4, 4}, true, true, false, false},
+		{"out of order", []float64{1, 3, 2}, false, false, false, false},
+		{"empty", nil, true, true, true, true},
+		{"NaN", []float64{1, QNaN64(), 2}, false, false, false, false},
+	}
+	for _, tc := range cases {
+		if got := IsMonotonicIncreasing(tc.vals); got != tc.inc {
+			t.Errorf("%s: IsMonotonicIncreasing = %v; want %v", tc.name, got, tc.inc)
+		}
+		if got := IsMonotonicDecreasing(tc.vals); got != tc.dec {
+			t.Errorf("%s: IsMonotonicDecreasing = %v; want %v", tc.name, got, tc.dec)
+		}
+		if got := IsStrictlyIncreasing(tc.vals); got != tc.sInc {
+			t.Errorf("%s: IsStrictlyIncreasing = %v; want %v", tc.name, got, tc.sInc)
+		}
+		if got := IsStrictlyDecreasing(tc.vals); got != tc.sDec {
+			t.Errorf("%s: IsStrictlyDecreasing = %v; want %v", tc.name, got, tc.sDec)
+		}
+	}
+}
+
+func TestArgMinArgMax(t *testing.T) {
+	type point struct{ x, y float64 }
+	points := []point{{3, 4}, {-1, 1}, {0, 5}, {1, -1}, {-4, 3}}
+	dist := func(p point) float64 { return math.Hypot(p.x, p.y) }
+	if got, idx := ArgMin(points, dist); idx != 1 || got != points[1] {
+		t.Errorf("ArgMin(distance) = %v, %d; want %v, 1 (earliest tie)", got, idx, points[1])
+	}
+	if got, idx := ArgMax(points, dist); idx != 0 || got != points[0] {
+		t.Errorf("ArgMax(distance) = %v, %d; want %v, 0 (earliest tie)", got, idx, points[0])
+	}
+	if _, idx := ArgMin([]point{}, dist); idx != -1 {
+		t.Errorf("ArgMin(empty) index = %d; want -1", idx)
+	}
+}
+
+func TestIntegrateTrapezoidXY(t *testing.T) {
+	// y = 2x is integrated exactly by trapezoids at any spacing.
+	xs := []float64{0, 0.1, 0.5, 1.7, 2, 3}
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = 2 * x
+	}
+	if got := IntegrateTrapezoidXY(xs, ys); !approxEqual(got, 9, 1e-12) {
+		t.Errorf("IntegrateTrapezoidXY(2x, uneven) = %v; want 9", got)
+	}
+	steps := IntegrateTrapezoidXY([]int{0, 1, 4}, []int{2, 4, 0})
+	if steps != 9 {
+		t.Errorf("IntegrateTrapezoidXY(uneven ints) = %v; want 9", steps)
+	}
+	if got := IntegrateTrapezoidXY([]float64{1}, []float64{5}); got != 0 {
+		t.Errorf("IntegrateTrapezoidXY(single point) = %v; want 0", got)
+	}
+}
+
+func TestDedupApprox(t *testing.T) {
+	got := DedupApprox([]float64{3.02, 1, 5, 2.99, 1.01, 3, 0.99}, 0.05)
+	want := []float64{1, 3.0033333333333334, 5}
+	if len(got) != len(want) {
+		t.Fatalf("DedupApprox(clustered) = %v; want %v", got, want)
+	}
+	for i := range want {
+		if !approxEqual(got[i], want[i], 1e-12) {
+			t.Errorf("DedupApprox(clustered) = %v; want %v", got, want)
+			break
+		}
+	}
+	distinct := []float64{4, 1, 3, 2}
+	got = DedupApprox(distinct, 0.5)
+	if len(got) != 4 || got[0] != 1 || got[3] != 4 || distinct[0] != 4 {
+		t.Errorf("DedupApprox(no duplicates) = %v with input %v; want [1 2 3 4] and input unchanged", got, distinct)
+	}
+}
+
+func equalSlices[T Real](a, b []T, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !approxEqual(float64(a[i]), float64(b[i]), tol) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLinspace(t *testing.T) {
+	if got := Linspace(0.0, 1, 5); !equalSlices(got, []float64{0, 0.25, 0.5, 0.75, 1}, 1e-15) {
+		t.Errorf("Linspace(0, 1, 5) = %v", got)
+	}
+	got := Linspace(0.1, 0.7, 7)
+	if got[0] != 0.1 || got[6] != 0.7 {
+		t.Errorf("Linspace(0.1, 0.7, 7) endpoints = %v, %v; want exact 0.1, 0.7", got[0], got[6])
+	}
+	if got := Linspace(3, 7, 1); !equalSlices(got, []int{3}, 0) {
+		t.Errorf("Linspace(3, 7, 1) = %v; want [3]", got)
+	}
+	if got := Linspace(0, 10, 0); got == nil || len(got) != 0 {
+		t.Errorf("Linspace(0, 10, 0) = %v; want empty", got)
+	}
+	if got := Logspace(0.0, 3, 4, 10); !equalSlices(got, []float64{1, 10, 100, 1000}, 1e-9) {
+		t.Errorf("Logspace(0, 3, 4, 10) = %v", got)
+	}
+}
+
+func TestArange(t *testing.T) {
+	if got := Arange(0, 5, 1); !equalSlices(got, []int{0, 1, 2, 3, 4}, 0) {
+		t.Errorf("Arange(0, 5, 1) = %v", got)
+	}
+	if got := Arange(5, 0, -2); !equalSlices(got, []int{5, 3, 1}, 0) {
+		t.Errorf("Arange(5, 0, -2) = %v", got)
+	}
+	if got := Arange(0.0, 1, 0.25); !equalSlices(got, []float64{0, 0.25, 0.5, 0.75}, 1e-15) {
+		t.Errorf("Arange(0, 1, 0.25) = %v", got)
+	}
+	if got := Arange(0, 5, -1); len(got) != 0 {
+		t.Errorf("Arange(0, 5, -1) = %v; want empty", got)
+	}
+	if got := Arange(0, 5, 0); got == nil || len(got) != 0 {
+		t.Errorf("Arange(0, 5, 0) = %v; want empty", got)
+	}
+	if got := Arange(uint8(2), 9, 3); !equalSlices(got, []uint8{2, 5, 8}, 0) {
+		t.Errorf("Arange(uint8(2), 9, 3) = %v", got)
+	}
+}
+
+func TestArangeInfiniteSpan(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "genmath: Arange count out of range" {
+			t.Errorf("Arange(0, +Inf, 1) panic = %v; want count out of range", r)
+		}
+	}()
+	Arange(0.0, math.Inf(1), 1)
+}
+
+func TestCountOutOfRange(t *testing.T) {
+	below, above := CountOutOfRange([]int{-3, 0, 5, 10, 11, 12, -1}, 0, 10)
+	if below != 2 || above != 2 {
+		t.Errorf("CountOutOfRange(mixed) = %d, %d; want 2, 2", below, above)
+	}
+	below, above = CountOutOfRange([]float64{0, 2.5, 10}, 0, 10)
+	if below != 0 || above != 0 {
+		t.Errorf("CountOutOfRange(in range) = %d, %d; want 0, 0", below, above)
+	}
+	below, above = CountOutOfRange([]float64{QNaN64(), -1}, 0, 10)
+	if below != 1 || above != 0 {
+		t.Errorf("CountOutOfRange(NaN, -1) = %d, %d; want 1, 0", below, above)
+	}
+}
+
+func TestBucketize(t *testing.T) {
+	edges := []float64{0, 10, 20}
+	cases := []struct {
+		val  float64
+		want int
+	}{
+		{-5, 0},
+		{0, 1},
+		{5, 1},
+		{10, 2},
+		{15, 2},
+		{19.999, 2},
+		{20, 3},
+		{100, 3},
+	}
+	for _, tc := range cases {
+		if got := Bucketize(tc.val, edges); got != tc.want {
+			t.Errorf("Bucketize(%v, %v) = %d; want %d", tc.val, edges, got, tc.want)
+		}
+	}
+	if got := Bucketize(7, []int(nil)); got != 0 {
+		t.Errorf("Bucketize(7, nil) = %d; want 0", got)
+	}
+}
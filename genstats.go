@@ -0,0 +1,247 @@
+package genmath
+
+import (
+	"math"
+	"sort"
+)
+
+func Median[T Real](vals []T) float64 {
+	return Percentile(vals, 50)
+}
+
+// Percentile returns the p-th percentile (p in [0, 100]) of vals, linearly
+// interpolating between the two nearest ranks. Empty input or a NaN p
+// returns NaN.
+func Percentile[T Real](vals []T, p float64) float64 {
+	if len(vals) == 0 || p != p {
+		return math.NaN()
+	}
+	work := append([]T(nil), vals...)
+	rank := Clamp(0, p, 100) / 100 * float64(len(work)-1)
+	k := int(rank)
+	frac := rank - float64(k)
+	lo := quickSelect(work, k)
+	if frac == 0 {
+		return float64(lo)
+	}
+	hi := work[k+1]
+	for _, val := range work[k+2:] {
+		hi = Min(hi, val)
+	}
+	return Lerp(float64(lo), float64(hi), frac)
+}
+
+// WilsonScore returns the Wilson score interval for positive successes out of
+// total trials at the given z (1.96 for 95% confidence). A total of zero
+// returns (0, 0).
+func WilsonScore[T Float](positive, total T, z T) (lower, upper T) {
+	if total == 0 {
+		return 0, 0
+	}
+	fPos, fTotal, fZ := float64(positive), float64(total), float64(z)
+	phat := fPos / fTotal
+	z2 := fZ * fZ
+	denom := 1 + z2/fTotal
+	center := phat + z2/(2*fTotal)
+	margin := fZ * math.Sqrt(phat*(1-phat)/fTotal+z2/(4*fTotal*fTotal))
+	fLower := Max(0, (center-margin)/denom)
+	fUpper := Min(1, (center+margin)/denom)
+	return T(fLower), T(fUpper)
+}
+
+// RunningMedian tracks the median of the most recent samples in a fixed-size
+// window. Until the window fills, the median covers every sample pushed so
+// far, and with no samples it is 0.
+type RunningMedian[T Real] struct {
+	window []T
+	sorted []T
+	next   int
+}
+
+func NewRunningMedian[T Real](size int) *RunningMedian[T] {
+	if size < 1 {
+		size = 1
+	}
+	return &RunningMedian[T]{
+		window: make([]T, 0, size),
+		sorted: make([]T, 0, size),
+	}
+}
+
+// Push adds val to the window, evicting the oldest sample once it is full.
+// NaN samples are ignored, since they have no place in the sorted order.
+func (r *RunningMedian[T]) Push(val T) {
+	if val != val {
+		return
+	}
+	if len(r.window) < cap(r.window) {
+		r.window = append(r.window, val)
+	} else {
+		old := r.window[r.next]
+		r.window[r.next] = val
+		r.next = (r.next + 1) % len(r.window)
+		idx := sort.Search(len(r.sorted), func(i int) bool { return r.sorted[i] >= old })
+		r.sorted = append(r.sorted[:idx], r.sorted[idx+1:]...)
+	}
+	idx := sort.Search(len(r.sorted), func(i int) bool { return r.sorted[i] >= val })
+	r.sorted = append(r.sorted, 0)
+	copy(r.sorted[idx+1:], r.sorted[idx:])
+	r.sorted[idx] = val
+}
+
+func (r *RunningMedian[T]) Median() T {
+	count := len(r.sorted)
+	if count == 0 {
+		return 0
+	}
+	mid := count / 2
+	if count%2 == 1 {
+		return r.sorted[mid]
+	}
+	return T((float64(r.sorted[mid-1]) + float64(r.sorted[mid])) / 2)
+}
+
+type comoments struct {
+	meanX, meanY float64
+	m2X, m2Y     float64
+	cXY          float64
+}
+
+// welford accumulates the means, second moments and co-moment of x and y in
+// a single numerically stable pass.
+func welford[T Real](x, y []T) comoments {
+	if len(x) != len(y) {
+		panic("genmath: slice length mismatch")
+	}
+	var m comoments
+	for i := range x {
+		fX, fY := float64(x[i]), float64(y[i])
+		count := float64(i + 1)
+		dX := fX - m.meanX
+		m.meanX += dX / count
+		dY := fY - m.meanY
+		m.meanY += dY / count
+		m.m2X += dX * (fX - m.meanX)
+		m.m2Y += dY * (fY - m.meanY)
+		m.cXY += dX * (fY - m.meanY)
+	}
+	return m
+}
+
+// Covariance returns the sample covariance of x and y. It panics if the
+// slices differ in length and returns NaN for fewer than 2 elements.
+func Covariance[T Real](x, y []T) float64 {
+	m := welford(x, y)
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	return m.cXY / float64(len(x)-1)
+}
+
+// Correlation returns the Pearson correlation coefficient of x and y. It
+// panics if the slices differ in length and returns NaN for fewer than 2
+// elements.
+func Correlation[T Real](x, y []T) float64 {
+	m := welford(x, y)
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	return Clamp(-1, m.cXY/math.Sqrt(m.m2X*m.m2Y), 1)
+}
+
+// LinearFit returns the least-squares line y = slope*x + intercept through the
+// points and its coefficient of determination r2. It panics if the slices
+// differ in length and returns NaNs for fewer than 2 points.
+func LinearFit[T Real](x, y []T) (slope, intercept, r2 float64) {
+	m := welford(x, y)
+	if len(x) < 2 {
+		nan := math.NaN()
+		return nan, nan, nan
+	}
+	slope = m.cXY / m.m2X
+	intercept = m.meanY - slope*m.meanX
+	if m.m2Y == 0 {
+		return slope, intercept, 1
+	}
+	r2 = m.cXY * m.cXY / (m.m2X * m.m2Y)
+	return slope, intercept, Clamp(0, r2, 1)
+}
+
+// AutoRange tracks the smallest and largest values observed so far for
+// streaming normalization. The zero value is ready to use.
+type AutoRange[T Real] struct {
+	min, max T
+	seen     bool
+}
+
+func (a *AutoRange[T]) Observe(val T) {
+	if !a.seen {
+		a.min, a.max, a.seen = val, val, true
+		return
+	}
+	a.min, a.max = Min(a.min, val), Max(a.max, val)
+}
+
+// Normalize returns where val lies within the observed range, 0 at the
+// smallest and 1 at the largest. Values outside the range are not clamped.
+// Before any observation, or while every observation has been equal, it
+// returns 0.5.
+func (a *AutoRange[T]) Normalize(val T) float64 {
+	if !a.seen || a.min == a.max {
+		return 0.5
+	}
+	return Range(float64(a.min), float64(a.max), float64(val))
+}
+
+// Summary describes the distribution of a slice of values. StdDev is the
+// sample standard deviation, and the percentiles interpolate like Percentile.
+type Summary struct {
+	Count  int
+	Min    float64
+	Max    float64
+	Mean   float64
+	Median float64
+	StdDev float64
+	P25    float64
+	P75    float64
+}
+
+// Summarize computes a Summary of vals with one pass for the moments and one
+// sort for the percentiles. Empty input returns the zero Summary.
+func Summarize[T Real](vals []T) Summary {
+	if len(vals) == 0 {
+		return Summary{}
+	}
+	sorted := make([]float64, len(vals))
+	mean, m2 := 0.0, 0.0
+	for i, val := range vals {
+		fVal := float64(val)
+		sorted[i] = fVal
+		delta := fVal - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (fVal - mean)
+	}
+	sort.Float64s(sorted)
+	summary := Summary{
+		Count:  len(sorted),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Mean:   mean,
+		Median: sortedPercentile(sorted, 50),
+		P25:    sortedPercentile(sorted, 25),
+		P75:    sortedPercentile(sorted, 75),
+	}
+	if len(sorted) > 1 {
+		summary.StdDev = math.Sqrt(m2 / float64(len(sorted)-1))
+	}
+	return summary
+}
+
+func sortedPercentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	k := int(rank)
+	if k >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return Lerp(sorted[k], sorted[k+1], rank-float64(k))
+}
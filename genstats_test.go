@@ -0,0 +1,174 @@
+package genmath
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestNthElementAndPercentileRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial += 1 {
+		vals := make([]int, 1+rng.Intn(50))
+		for i := range vals {
+			vals[i] = rng.Intn(20) - 10
+		}
+		sorted := append([]int(nil), vals...)
+		sort.Ints(sorted)
+		k := rng.Intn(len(vals))
+		if got := NthElement(vals, k); got != sorted[k] {
+			t.Fatalf("NthElement(%v, %d) = %d; want %d", vals, k, got, sorted[k])
+		}
+		p := rng.Float64() * 100
+		rank := p / 100 * float64(len(sorted)-1)
+		lo := int(rank)
+		want := float64(sorted[lo])
+		if lo+1 < len(sorted) {
+			want = Lerp(float64(sorted[lo]), float64(sorted[lo+1]), rank-float64(lo))
+		}
+		if got := Percentile(vals, p); !approxEqual(got, want, 1e-9) {
+			t.Fatalf("Percentile(%v, %v) = %v; want %v", vals, p, got, want)
+		}
+	}
+}
+
+func TestPercentileNaN(t *testing.T) {
+	if got := Percentile([]float64{1, 2, 3}, math.NaN()); !math.IsNaN(got) {
+		t.Errorf("Percentile(vals, NaN) = %v; want NaN", got)
+	}
+	if got := Median([]int{}); !math.IsNaN(got) {
+		t.Errorf("Median(empty) = %v; want NaN", got)
+	}
+}
+
+func TestWilsonScore(t *testing.T) {
+	cases := []struct{ positive, total, lower, upper float64 }{
+		{5, 10, 0.2366, 0.7634},
+		{0, 10, 0, 0.2775},
+		{10, 10, 0.7225, 1},
+		{81, 100, 0.7222, 0.8749},
+	}
+	for _, tc := range cases {
+		lower, upper := WilsonScore(tc.positive, tc.total, 1.96)
+		if !approxEqual(lower, tc.lower, 1e-4) || !approxEqual(upper, tc.upper, 1e-4) {
+			t.Errorf("WilsonScore(%v, %v, 1.96) = (%v, %v); want (%v, %v)", tc.positive, tc.total, lower, upper, tc.lower, tc.upper)
+		}
+	}
+	if lower, upper := WilsonScore(0.0, 0, 1.96); lower != 0 || upper != 0 {
+		t.Errorf("WilsonScore(0, 0) = (%v, %v); want (0, 0)", lower, upper)
+	}
+}
+
+func TestRunningMedianSpikeRemoval(t *testing.T) {
+	median := NewRunningMedian[float64](3)
+	input := []float64{1, 1, 100, 1, 1, -50, 2, 2}
+	want := []float64{1, 1, 1, 1, 1, 1, 1, 2}
+	for i, val := range input {
+		median.Push(val)
+		if got := median.Median(); got != want[i] {
+			t.Errorf("after pushing %v: Median() = %v; want %v", input[:i+1], got, want[i])
+		}
+	}
+}
+
+func TestRunningMedianNaN(t *testing.T) {
+	median := NewRunningMedian[float64](3)
+	for _, val := range []float64{1, math.NaN(), 2, 3, 4, 5} {
+		median.Push(val)
+	}
+	if got := median.Median(); got != 4 {
+		t.Errorf("Median() after 1, NaN, 2, 3, 4, 5 = %v; want 4", got)
+	}
+}
+
+func TestCovarianceCorrelation(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	up := []float64{2, 4, 6, 8, 10}
+	down := []float64{5, 4, 3, 2, 1}
+	if got := Covariance(x, up); !approxEqual(got, 5, 1e-12) {
+		t.Errorf("Covariance(x, 2x) = %v; want 5", got)
+	}
+	if got := Correlation(x, up); !approxEqual(got, 1, 1e-12) {
+		t.Errorf("Correlation(x, 2x) = %v; want 1", got)
+	}
+	if got := Covariance(x, down); !approxEqual(got, -2.5, 1e-12) {
+		t.Errorf("Covariance(x, -x) = %v; want -2.5", got)
+	}
+	if got := Correlation(x, down); !approxEqual(got, -1, 1e-12) {
+		t.Errorf("Correlation(x, -x) = %v; want -1", got)
+	}
+	flat := []float64{1, 2, 3, 4}
+	wave := []float64{1, -1, -1, 1}
+	if got := Covariance(flat, wave); !approxEqual(got, 0, 1e-12) {
+		t.Errorf("Covariance(uncorrelated) = %v; want 0", got)
+	}
+	if got := Correlation(flat, wave); !approxEqual(got, 0, 1e-12) {
+		t.Errorf("Correlation(uncorrelated) = %v; want 0", got)
+	}
+	if got := Covariance([]int{1}, []int{2}); !math.IsNaN(got) {
+		t.Errorf("Covariance(single) = %v; want NaN", got)
+	}
+}
+
+func TestLinearFit(t *testing.T) {
+	slope, intercept, r2 := LinearFit([]float64{0, 1, 2, 3}, []float64{1, 4, 7, 10})
+	if !approxEqual(slope, 3, 1e-12) || !approxEqual(intercept, 1, 1e-12) || r2 != 1 {
+		t.Errorf("LinearFit(exact line) = %v, %v, %v; want 3, 1, 1", slope, intercept, r2)
+	}
+	slope, intercept, r2 = LinearFit([]float64{0, 1, 2, 3, 4}, []float64{1.1, 2.9, 5.2, 6.8, 9.1})
+	if !approxEqual(slope, 1.99, 1e-9) || !approxEqual(intercept, 1.04, 1e-9) || !approxEqual(r2, 0.997305, 1e-6) {
+		t.Errorf("LinearFit(noisy) = %v, %v, %v; want 1.99, 1.04, 0.997305", slope, intercept, r2)
+	}
+}
+
+func TestAutoRange(t *testing.T) {
+	var auto AutoRange[int]
+	if got := auto.Normalize(7); got != 0.5 {
+		t.Errorf("Normalize before any observation = %v; want 0.5", got)
+	}
+	auto.Observe(10)
+	if got := auto.Normalize(3); got != 0.5 {
+		t.Errorf("Normalize with a single observation = %v; want 0.5", got)
+	}
+	for _, val := range []int{20, 15, 12} {
+		auto.Observe(val)
+	}
+	cases := []struct {
+		val  int
+		want float64
+	}{
+		{10, 0},
+		{15, 0.5},
+		{20, 1},
+		{5, -0.5},
+		{30, 2},
+	}
+	for _, tc := range cases {
+		if got := auto.Normalize(tc.val); got != tc.want {
+			t.Errorf("Normalize(%d) after observing [10, 20] = %v; want %v", tc.val, got, tc.want)
+		}
+	}
+	var small AutoRange[uint8]
+	small.Observe(10)
+	small.Observe(20)
+	if got := small.Normalize(5); got != -0.5 {
+		t.Errorf("uint8 Normalize(5) = %v; want -0.5", got)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	got := Summarize([]int{7, 1, 3, 9, 5, 2, 8})
+	want := Summary{Count: 7, Min: 1, Max: 9, Mean: 5, Median: 5, StdDev: 3.1091263510296048, P25: 2.5, P75: 7.5}
+	if got.Count != want.Count || got.Min != want.Min || got.Max != want.Max ||
+		!approxEqual(got.Mean, want.Mean, 1e-12) || got.Median != want.Median ||
+		!approxEqual(got.StdDev, want.StdDev, 1e-12) || got.P25 != want.P25 || got.P75 != want.P75 {
+		t.Errorf("Summarize(known) = %+v; want %+v", got, want)
+	}
+	if got := Summarize([]float64{4}); got != (Summary{Count: 1, Min: 4, Max: 4, Mean: 4, Median: 4, P25: 4, P75: 4}) {
+		t.Errorf("Summarize([4]) = %+v", got)
+	}
+	if got := Summarize([]float64(nil)); got != (Summary{}) {
+		t.Errorf("Summarize(nil) = %+v; want zero Summary", got)
+	}
+}
@@ -0,0 +1,11 @@
+package genmath
+
+import "time"
+
+func ClampDuration(min, val, max time.Duration) time.Duration {
+	return Clamp(min, val, max)
+}
+
+func LerpDuration(start, end time.Duration, amount float64) time.Duration {
+	return Lerp(start, end, amount)
+}
@@ -0,0 +1,36 @@
+package genmath
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLerpDuration(t *testing.T) {
+	cases := []struct {
+		amount float64
+		want   time.Duration
+	}{
+		{0, time.Second},
+		{0.5, 2 * time.Second},
+		{1, 3 * time.Second},
+		{0.25, 1500 * time.Millisecond},
+	}
+	for _, tc := range cases {
+		if got := LerpDuration(time.Second, 3*time.Second, tc.amount); got != tc.want {
+			t.Errorf("LerpDuration(1s, 3s, %v) = %v; want %v", tc.amount, got, tc.want)
+		}
+	}
+}
+
+func TestClampDuration(t *testing.T) {
+	cases := []struct{ val, want time.Duration }{
+		{time.Millisecond, time.Second},
+		{time.Hour, time.Minute},
+		{10 * time.Second, 10 * time.Second},
+	}
+	for _, tc := range cases {
+		if got := ClampDuration(time.Second, tc.val, time.Minute); got != tc.want {
+			t.Errorf("ClampDuration(1s, %v, 1m) = %v; want %v", tc.val, got, tc.want)
+		}
+	}
+}